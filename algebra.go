@@ -0,0 +1,262 @@
+package sset
+
+import (
+	"cmp"
+	"fmt"
+	"slices"
+)
+
+// Number is constraint for ranks which can be weighted and summed, as done
+// by Union and Intersect
+type Number interface {
+	~int | ~int8 | ~int16 | ~int32 | ~int64 |
+		~uint | ~uint8 | ~uint16 | ~uint32 | ~uint64 | ~uintptr |
+		~float32 | ~float64
+}
+
+// Aggregate decides how Union and Intersect combine ranks of member present
+// in multiple sorted sets
+type Aggregate int
+
+const (
+	// AggregateSum sums weighted ranks
+	AggregateSum Aggregate = iota
+	// AggregateMin takes minimum of weighted ranks
+	AggregateMin
+	// AggregateMax takes maximum of weighted ranks
+	AggregateMax
+)
+
+// Union returns new sorted set holding members of all sets, just like redis
+// ZUNIONSTORE. Rank of each member is rank in each set it is present in,
+// multiplied by weight of that set, and combined by aggregate.
+// Nil weights weigh every set by 1, otherwise there must be one weight per
+// set, or ErrInvalidOptions is returned. Nil sets are treated as empty.
+// Just like redis, NaN resulting from weighting or summing infinite ranks is
+// replaced by 0, while integer overflow wraps around.
+// Input sets are read locked together for the entire operation.
+// Time complexity: O(n * log(n)) where n is total number of members of sets
+func Union[M comparable, S Number](sets []*Set[M, S], weights []S, aggregate Aggregate) (*Set[M, S], error) {
+	weights, err := checkWeights(sets, weights, aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := rlockAll(sets)
+	defer unlock()
+
+	ranks := map[M]S{}
+	for i, set := range sets {
+		if set == nil {
+			continue
+		}
+
+		for member, rank := range set.dict {
+			rank = weigh(rank, weights[i])
+			if current, ok := ranks[member]; ok {
+				rank = combine(current, rank, aggregate)
+			}
+			ranks[member] = rank
+		}
+	}
+
+	return newSetFrom(ranks), nil
+}
+
+// Intersect returns new sorted set holding members present in every one of
+// sets, just like redis ZINTERSTORE. Ranks are weighted and combined just
+// like Union. Nil sets are treated as empty, so result is empty.
+// Input sets are read locked together for the entire operation.
+// Time complexity: O(n * m + r * log(r)) where n is number of members of
+// smallest set, m is number of sets and r is number of members in result
+func Intersect[M comparable, S Number](sets []*Set[M, S], weights []S, aggregate Aggregate) (*Set[M, S], error) {
+	weights, err := checkWeights(sets, weights, aggregate)
+	if err != nil {
+		return nil, err
+	}
+
+	unlock := rlockAll(sets)
+	defer unlock()
+
+	ranks := map[M]S{}
+	if len(sets) == 0 || slices.Contains(sets, nil) {
+		return newSetFrom(ranks), nil
+	}
+
+	// Only members of smallest set can be present in all of them
+	smallest := slices.MinFunc(sets, func(a, b *Set[M, S]) int {
+		return cmp.Compare(len(a.dict), len(b.dict))
+	})
+
+members:
+	for member := range smallest.dict {
+		var combined S
+		for i, set := range sets {
+			rank, ok := set.dict[member]
+			if !ok {
+				continue members
+			}
+
+			rank = weigh(rank, weights[i])
+			if i > 0 {
+				rank = combine(combined, rank, aggregate)
+			}
+			combined = rank
+		}
+		ranks[member] = combined
+	}
+
+	return newSetFrom(ranks), nil
+}
+
+// Diff returns new sorted set holding members of first of sets which are not
+// present in any other set, with their ranks in first set, just like redis
+// ZDIFF. Nil sets are treated as empty.
+// Input sets are read locked together for the entire operation.
+// Time complexity: O(n * m + r * log(r)) where n is number of members of
+// first set, m is number of sets and r is number of members in result
+func Diff[M comparable, S cmp.Ordered](sets []*Set[M, S]) *Set[M, S] {
+	unlock := rlockAll(sets)
+	defer unlock()
+
+	ranks := map[M]S{}
+	if len(sets) == 0 || sets[0] == nil {
+		return newSetFrom(ranks)
+	}
+
+	for member, rank := range sets[0].dict {
+		if !presentInOther(sets, 0, member) {
+			ranks[member] = rank
+		}
+	}
+
+	return newSetFrom(ranks)
+}
+
+// SymmetricDifference returns new sorted set holding members present in
+// exactly one of sets, with their ranks in that set. Nil sets are treated as
+// empty.
+// Input sets are read locked together for the entire operation.
+// Time complexity: O(n * m + r * log(r)) where n is total number of members
+// of sets, m is number of sets and r is number of members in result
+func SymmetricDifference[M comparable, S cmp.Ordered](sets []*Set[M, S]) *Set[M, S] {
+	unlock := rlockAll(sets)
+	defer unlock()
+
+	ranks := map[M]S{}
+	for i, set := range sets {
+		if set == nil {
+			continue
+		}
+
+		for member, rank := range set.dict {
+			if !presentInOther(sets, i, member) {
+				ranks[member] = rank
+			}
+		}
+	}
+
+	return newSetFrom(ranks)
+}
+
+// presentInOther reports whether member is present in any of sets other than
+// the one at index i. Caller must hold read locks of sets.
+func presentInOther[M comparable, S cmp.Ordered](sets []*Set[M, S], i int, member M) bool {
+	for j, set := range sets {
+		if j == i || set == nil {
+			continue
+		}
+
+		if _, ok := set.dict[member]; ok {
+			return true
+		}
+	}
+	return false
+}
+
+// checkWeights validates weights and aggregate, returning weight of 1 for
+// every set if weights are nil
+func checkWeights[M comparable, S Number](sets []*Set[M, S], weights []S, aggregate Aggregate) ([]S, error) {
+	if aggregate != AggregateSum && aggregate != AggregateMin && aggregate != AggregateMax {
+		return nil, fmt.Errorf("%w: unknown aggregate %d", ErrInvalidOptions, aggregate)
+	}
+
+	if weights == nil {
+		weights = make([]S, len(sets))
+		for i := range weights {
+			weights[i] = 1
+		}
+	}
+
+	if len(weights) != len(sets) {
+		return nil, fmt.Errorf("%w: got %d weights for %d sets", ErrInvalidOptions, len(weights), len(sets))
+	}
+
+	return weights, nil
+}
+
+// weigh multiplies rank by weight, replacing NaN with 0
+func weigh[S Number](rank, weight S) S {
+	rank *= weight
+	if isNaN(rank) {
+		return 0
+	}
+	return rank
+}
+
+// combine combines two ranks of member by aggregate, replacing NaN with 0
+func combine[S Number](a, b S, aggregate Aggregate) S {
+	switch aggregate {
+	case AggregateMin:
+		return min(a, b)
+	case AggregateMax:
+		return max(a, b)
+	}
+
+	if sum := a + b; !isNaN(sum) {
+		return sum
+	}
+	return 0
+}
+
+// newSetFrom creates sorted set holding given members with their ranks
+func newSetFrom[M comparable, S cmp.Ordered](ranks map[M]S) *Set[M, S] {
+	s := &Set[M, S]{}
+	s.lazyInit()
+
+	// Set is not shared yet, so it does not need locking
+	for member, rank := range ranks {
+		s.dict[member] = rank
+		s.link(member, rank)
+	}
+
+	return s
+}
+
+// rlockAll acquires read locks of sets in order of their id, so that
+// goroutines locking overlapping sorted sets never wait on each other in a
+// cycle. Set present multiple times is locked once, and nil sets are
+// skipped. It returns function releasing all the locks.
+func rlockAll[M comparable, S cmp.Ordered](sets []*Set[M, S]) func() {
+	unique := make([]*Set[M, S], 0, len(sets))
+	for _, set := range sets {
+		if set != nil && !slices.Contains(unique, set) {
+			set.lazyInit()
+			unique = append(unique, set)
+		}
+	}
+
+	slices.SortFunc(unique, func(a, b *Set[M, S]) int {
+		return cmp.Compare(a.id, b.id)
+	})
+
+	for _, set := range unique {
+		set.rlock()
+	}
+
+	return func() {
+		for i := len(unique) - 1; i >= 0; i-- {
+			unique[i].runlock()
+		}
+	}
+}
@@ -0,0 +1,206 @@
+package sset
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"sync"
+	"testing"
+)
+
+// newSortedSet creates sorted set holding given members with their ranks
+func newSortedSet(entries ...Entry) *SortedSet {
+	s := &SortedSet{}
+	for _, entry := range entries {
+		s.MustAdd(entry.Member, entry.Score)
+	}
+	return s
+}
+
+func TestSortedSetAlgebra(t *testing.T) {
+	t.Run("Union", func(t *testing.T) {
+		a := newSortedSet(Entry{"a", 1}, Entry{"b", 2}, Entry{"c", 3})
+		b := newSortedSet(Entry{"b", 10}, Entry{"c", -5}, Entry{"d", 4})
+
+		expected := map[Aggregate]string{
+			AggregateSum: "[{c -2} {a 1} {d 4} {b 12}]",
+			AggregateMin: "[{c -5} {a 1} {b 2} {d 4}]",
+			AggregateMax: "[{a 1} {c 3} {d 4} {b 10}]",
+		}
+		for aggregate, entries := range expected {
+			result := MustUnion([]*SortedSet{a, b}, nil, aggregate)
+			if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != entries {
+				t.Errorf("Union with aggregate %d expected: %s, got: %v", aggregate, entries, result.GetByIndexRangeWithScores(0, -1))
+				return
+			}
+		}
+
+		result := MustUnion([]*SortedSet{a, nil, b}, []int{2, 7, -1}, AggregateSum)
+		if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != "[{b -6} {d -4} {a 2} {c 11}]" {
+			t.Errorf("Wrong entries in weighted union: %v", result.GetByIndexRangeWithScores(0, -1))
+			return
+		}
+
+		result.MustAdd("e", 0)
+		if a.Exists("e") || b.Exists("e") || a.Len() != 3 {
+			t.Errorf("Union is not independent of input sets")
+			return
+		}
+	})
+
+	t.Run("Intersect", func(t *testing.T) {
+		a := newSortedSet(Entry{"a", 1}, Entry{"b", 2}, Entry{"c", 3})
+		b := newSortedSet(Entry{"b", 10}, Entry{"c", -5}, Entry{"d", 4})
+		c := newSortedSet(Entry{"c", 1}, Entry{"b", 1})
+
+		result := MustIntersect([]*SortedSet{a, b, c}, []int{1, 2, 3}, AggregateSum)
+		if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != "[{c -4} {b 25}]" {
+			t.Errorf("Wrong entries in weighted intersection: %v", result.GetByIndexRangeWithScores(0, -1))
+			return
+		}
+
+		result = MustIntersect([]*SortedSet{a, b}, nil, AggregateMax)
+		if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != "[{c 3} {b 10}]" {
+			t.Errorf("Wrong entries in intersection: %v", result.GetByIndexRangeWithScores(0, -1))
+			return
+		}
+
+		if MustIntersect([]*SortedSet{a, nil}, nil, AggregateSum).Len() != 0 || MustIntersect([]*SortedSet{}, nil, AggregateSum).Len() != 0 {
+			t.Errorf("Intersection with nil or no sets is not empty")
+			return
+		}
+	})
+
+	t.Run("SameSetMultipleTimes", func(t *testing.T) {
+		a := newSortedSet(Entry{"a", 1}, Entry{"b", 2})
+
+		result := MustUnion([]*SortedSet{a, a, a}, nil, AggregateSum)
+		if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != "[{a 3} {b 6}]" {
+			t.Errorf("Wrong entries in union of same set: %v", result.GetByIndexRangeWithScores(0, -1))
+			return
+		}
+
+		result = MustIntersect([]*SortedSet{a, a}, nil, AggregateMin)
+		if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != "[{a 1} {b 2}]" {
+			t.Errorf("Wrong entries in intersection of same set: %v", result.GetByIndexRangeWithScores(0, -1))
+			return
+		}
+	})
+
+	t.Run("InfiniteScores", func(t *testing.T) {
+		a := Set[string, float64]{}
+		a.MustAdd("a", math.Inf(1))
+		a.MustAdd("b", 1)
+		b := Set[string, float64]{}
+		b.MustAdd("a", math.Inf(-1))
+		b.MustAdd("b", 2)
+
+		result := MustUnion([]*Set[string, float64]{&a, &b}, nil, AggregateSum)
+		if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != "[{a 0} {b 3}]" {
+			t.Errorf("Sum of opposite infinities expected to be 0, got: %v", result.GetByIndexRangeWithScores(0, -1))
+			return
+		}
+
+		result = MustIntersect([]*Set[string, float64]{&a, &b}, []float64{0, 1}, AggregateMax)
+		if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != "[{a 0} {b 2}]" {
+			t.Errorf("Infinity weighted by 0 expected to be 0, got: %v", result.GetByIndexRangeWithScores(0, -1))
+			return
+		}
+	})
+
+	t.Run("Diff", func(t *testing.T) {
+		yesterday := newSortedSet(Entry{"a", 10}, Entry{"b", 20}, Entry{"c", 30}, Entry{"d", 40})
+		today := newSortedSet(Entry{"b", 25}, Entry{"d", 45}, Entry{"e", 50})
+		banned := newSortedSet(Entry{"c", 0})
+
+		result := Diff([]*SortedSet{yesterday, today})
+		if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != "[{a 10} {c 30}]" {
+			t.Errorf("Wrong entries in difference: %v", result.GetByIndexRangeWithScores(0, -1))
+			return
+		}
+
+		result = Diff([]*SortedSet{yesterday, nil, today, banned})
+		if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != "[{a 10}]" {
+			t.Errorf("Wrong entries in difference of multiple sets: %v", result.GetByIndexRangeWithScores(0, -1))
+			return
+		}
+
+		if Diff([]*SortedSet{yesterday, yesterday}).Len() != 0 || Diff([]*SortedSet{nil, today}).Len() != 0 || Diff([]*SortedSet{}).Len() != 0 {
+			t.Errorf("Difference with itself, nil or no sets is not empty")
+			return
+		}
+
+		if Diff([]*SortedSet{today}).Len() != 3 {
+			t.Errorf("Difference of single set expected %d members, got: %d", 3, Diff([]*SortedSet{today}).Len())
+			return
+		}
+	})
+
+	t.Run("SymmetricDifference", func(t *testing.T) {
+		a := newSortedSet(Entry{"a", 10}, Entry{"b", 20}, Entry{"c", 30})
+		b := newSortedSet(Entry{"b", 25}, Entry{"d", 5})
+		c := newSortedSet(Entry{"c", 35}, Entry{"d", 6}, Entry{"e", 1})
+
+		result := SymmetricDifference([]*SortedSet{a, b})
+		if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != "[{d 5} {a 10} {c 30}]" {
+			t.Errorf("Wrong entries in symmetric difference: %v", result.GetByIndexRangeWithScores(0, -1))
+			return
+		}
+
+		result = SymmetricDifference([]*SortedSet{a, nil, b, c})
+		if fmt.Sprint(result.GetByIndexRangeWithScores(0, -1)) != "[{e 1} {a 10}]" {
+			t.Errorf("Wrong entries in symmetric difference of multiple sets: %v", result.GetByIndexRangeWithScores(0, -1))
+			return
+		}
+
+		if SymmetricDifference([]*SortedSet{a, a}).Len() != 0 {
+			t.Errorf("Symmetric difference with itself is not empty")
+			return
+		}
+	})
+
+	t.Run("InvalidOptions", func(t *testing.T) {
+		a := newSortedSet(Entry{"a", 1})
+
+		if _, err := Union([]*SortedSet{a, a}, []int{1}, AggregateSum); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Union with wrong number of weights expected error: %v, got: %v", ErrInvalidOptions, err)
+			return
+		}
+
+		if _, err := Intersect([]*SortedSet{a}, nil, Aggregate(3)); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Intersect with unknown aggregate expected error: %v, got: %v", ErrInvalidOptions, err)
+			return
+		}
+	})
+
+	t.Run("ConcurrentOverlappingSets", func(t *testing.T) {
+		sets := []*SortedSet{{}, {}, {}}
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 6; i++ {
+			wg.Add(2)
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					sets[(i+j)%3].MustAdd(fmt.Sprint(j), j)
+				}
+			}()
+			go func() {
+				defer wg.Done()
+				for j := 0; j < 100; j++ {
+					// Sets in different order in every goroutine
+					MustUnion([]*SortedSet{sets[i%3], sets[(i+1)%3], sets[(i+2)%3]}, nil, AggregateSum)
+					MustIntersect([]*SortedSet{sets[(i+2)%3], sets[i%3]}, nil, AggregateMin)
+					Diff([]*SortedSet{sets[(i+1)%3], sets[i%3]})
+					SymmetricDifference([]*SortedSet{sets[(i+2)%3], sets[(i+1)%3]})
+				}
+			}()
+		}
+		wg.Wait()
+
+		if MustUnion(sets, nil, AggregateMax).Len() != 100 {
+			t.Errorf("Union of concurrently filled sets expected %d members, got: %d", 100, MustUnion(sets, nil, AggregateMax).Len())
+			return
+		}
+	})
+}
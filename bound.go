@@ -0,0 +1,78 @@
+package sset
+
+import (
+	"cmp"
+	"fmt"
+
+	"github.com/parthdesai/sset/internals"
+)
+
+// MemberBound represents one end of range of members, ordered just like
+// members sharing same rank, used by GetLexRange, LexCount and
+// RemoveRangeByLex.
+type MemberBound[M comparable] = internals.LexBound[M]
+
+// LexBound represents one end of lexicographical range of string members
+type LexBound = MemberBound[string]
+
+// LexInclusive returns bound which includes member itself
+func LexInclusive[M comparable](member M) MemberBound[M] {
+	return MemberBound[M]{Value: member}
+}
+
+// LexExclusive returns bound which excludes member itself
+func LexExclusive[M comparable](member M) MemberBound[M] {
+	return MemberBound[M]{Value: member, Exclusive: true}
+}
+
+// LexUnbounded returns bound which leaves range open at that end. Member
+// type can not be inferred, so it is given explicitly, e.g.
+// LexUnbounded[string]().
+func LexUnbounded[M comparable]() MemberBound[M] {
+	return MemberBound[M]{Unbounded: true}
+}
+
+// Bound represents one end of range of ranks of type S
+type Bound[S cmp.Ordered] = internals.KeyBound[S]
+
+// Interval represents range of ranks of type S in between Min and Max, used
+// by GetRange and GetRangeReverse.
+type Interval[S cmp.Ordered] = internals.KeyRange[S]
+
+// ScoreBound represents one end of range of int ranks
+type ScoreBound = Bound[int]
+
+// ScoreRange represents range of int ranks in between Min and Max
+type ScoreRange = Interval[int]
+
+// ScoreInclusive returns bound which includes rank itself
+func ScoreInclusive[S cmp.Ordered](rank S) Bound[S] {
+	return Bound[S]{Key: rank}
+}
+
+// ScoreExclusive returns bound which excludes rank itself
+func ScoreExclusive[S cmp.Ordered](rank S) Bound[S] {
+	return Bound[S]{Key: rank, Exclusive: true}
+}
+
+// ScoreUnbounded returns bound which leaves range open at that end. Rank
+// type can not be inferred, so it is given explicitly, e.g.
+// ScoreUnbounded[int]().
+func ScoreUnbounded[S cmp.Ordered]() Bound[S] {
+	return Bound[S]{Unbounded: true}
+}
+
+// allScores returns range of all ranks
+func allScores[S cmp.Ordered]() Interval[S] {
+	return Interval[S]{Min: ScoreUnbounded[S](), Max: ScoreUnbounded[S]()}
+}
+
+// checkRange returns ErrInvalidRange if either bound of scoreRange is NaN
+func checkRange[S cmp.Ordered](scoreRange Interval[S]) error {
+	for _, bound := range []Bound[S]{scoreRange.Min, scoreRange.Max} {
+		if !bound.Unbounded && isNaN(bound.Key) {
+			return fmt.Errorf("%w: bound is not a number", ErrInvalidRange)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,213 @@
+package sset
+
+import (
+	"cmp"
+	"reflect"
+	"strings"
+	"unsafe"
+)
+
+// compareMembers returns function ordering members sharing same rank.
+// Strings, numbers and booleans are ordered naturally, arrays and structs
+// element by element, and pointers and channels by their address. Members
+// held in interfaces are ordered by their dynamic type first.
+// Comparison is built once per type, so that only members holding interfaces
+// are compared with reflection, which allocates on every comparison.
+func compareMembers[M comparable]() func(a, b M) int {
+	switch any(*new(M)).(type) {
+	case string:
+		return compareOrdered[M, string]
+	case int:
+		return compareOrdered[M, int]
+	case int8:
+		return compareOrdered[M, int8]
+	case int16:
+		return compareOrdered[M, int16]
+	case int32:
+		return compareOrdered[M, int32]
+	case int64:
+		return compareOrdered[M, int64]
+	case uint:
+		return compareOrdered[M, uint]
+	case uint8:
+		return compareOrdered[M, uint8]
+	case uint16:
+		return compareOrdered[M, uint16]
+	case uint32:
+		return compareOrdered[M, uint32]
+	case uint64:
+		return compareOrdered[M, uint64]
+	case uintptr:
+		return compareOrdered[M, uintptr]
+	case float32:
+		return compareOrdered[M, float32]
+	case float64:
+		return compareOrdered[M, float64]
+	case bool:
+		return func(a, b M) int {
+			return cmp.Compare(boolToInt(any(a).(bool)), boolToInt(any(b).(bool)))
+		}
+	}
+
+	if fields, ok := scalarFields(reflect.TypeFor[M](), 0, nil); ok {
+		return func(a, b M) int {
+			pa, pb := unsafe.Pointer(&a), unsafe.Pointer(&b)
+			for _, field := range fields {
+				if c := compareScalar(field.kind, unsafe.Add(pa, field.offset), unsafe.Add(pb, field.offset)); c != 0 {
+					return c
+				}
+			}
+			return 0
+		}
+	}
+
+	return func(a, b M) int {
+		return compareValues(reflect.ValueOf(&a).Elem(), reflect.ValueOf(&b).Elem())
+	}
+}
+
+// scalarField is field of scalar kind at offset from start of member
+type scalarField struct {
+	offset uintptr
+	kind   reflect.Kind
+}
+
+// scalarFields flattens arrays and structs of type t, found at offset, into
+// scalar fields in order of comparison, appending them to fields. It reports
+// false if t holds interfaces, whose dynamic type is not known upfront.
+func scalarFields(t reflect.Type, offset uintptr, fields []scalarField) ([]scalarField, bool) {
+	ok := true
+
+	switch t.Kind() {
+	case reflect.Array:
+		for i := 0; i < t.Len() && ok; i++ {
+			fields, ok = scalarFields(t.Elem(), offset+uintptr(i)*t.Elem().Size(), fields)
+		}
+		return fields, ok
+	case reflect.Struct:
+		for i := 0; i < t.NumField() && ok; i++ {
+			// Blank fields are ignored by == as well
+			if field := t.Field(i); field.Name != "_" {
+				fields, ok = scalarFields(field.Type, offset+field.Offset, fields)
+			}
+		}
+		return fields, ok
+	case reflect.Interface:
+		return nil, false
+	}
+
+	return append(fields, scalarField{offset: offset, kind: t.Kind()}), true
+}
+
+// compareScalar compares two values of scalar kind, pointed to by a and b
+func compareScalar(kind reflect.Kind, a, b unsafe.Pointer) int {
+	switch kind {
+	case reflect.Bool:
+		return cmp.Compare(boolToInt(*(*bool)(a)), boolToInt(*(*bool)(b)))
+	case reflect.Int:
+		return cmp.Compare(*(*int)(a), *(*int)(b))
+	case reflect.Int8:
+		return cmp.Compare(*(*int8)(a), *(*int8)(b))
+	case reflect.Int16:
+		return cmp.Compare(*(*int16)(a), *(*int16)(b))
+	case reflect.Int32:
+		return cmp.Compare(*(*int32)(a), *(*int32)(b))
+	case reflect.Int64:
+		return cmp.Compare(*(*int64)(a), *(*int64)(b))
+	case reflect.Uint:
+		return cmp.Compare(*(*uint)(a), *(*uint)(b))
+	case reflect.Uint8:
+		return cmp.Compare(*(*uint8)(a), *(*uint8)(b))
+	case reflect.Uint16:
+		return cmp.Compare(*(*uint16)(a), *(*uint16)(b))
+	case reflect.Uint32:
+		return cmp.Compare(*(*uint32)(a), *(*uint32)(b))
+	case reflect.Uint64:
+		return cmp.Compare(*(*uint64)(a), *(*uint64)(b))
+	case reflect.Uintptr:
+		return cmp.Compare(*(*uintptr)(a), *(*uintptr)(b))
+	case reflect.Float32:
+		return cmp.Compare(*(*float32)(a), *(*float32)(b))
+	case reflect.Float64:
+		return cmp.Compare(*(*float64)(a), *(*float64)(b))
+	case reflect.Complex64:
+		return compareComplex(complex128(*(*complex64)(a)), complex128(*(*complex64)(b)))
+	case reflect.Complex128:
+		return compareComplex(*(*complex128)(a), *(*complex128)(b))
+	case reflect.String:
+		return strings.Compare(*(*string)(a), *(*string)(b))
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return cmp.Compare(uintptr(*(*unsafe.Pointer)(a)), uintptr(*(*unsafe.Pointer)(b)))
+	}
+
+	panic("unsupported member kind " + kind.String())
+}
+
+// compareComplex orders complex numbers by real part, then imaginary part
+func compareComplex(a, b complex128) int {
+	if c := cmp.Compare(real(a), real(b)); c != 0 {
+		return c
+	}
+	return cmp.Compare(imag(a), imag(b))
+}
+
+// compareOrdered compares members of type M, which is known to be T
+func compareOrdered[M comparable, T cmp.Ordered](a, b M) int {
+	return cmp.Compare(any(a).(T), any(b).(T))
+}
+
+// compareValues compares two values of same comparable type
+func compareValues(a, b reflect.Value) int {
+	switch a.Kind() {
+	case reflect.Bool:
+		return cmp.Compare(boolToInt(a.Bool()), boolToInt(b.Bool()))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return cmp.Compare(a.Int(), b.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64, reflect.Uintptr:
+		return cmp.Compare(a.Uint(), b.Uint())
+	case reflect.Float32, reflect.Float64:
+		return cmp.Compare(a.Float(), b.Float())
+	case reflect.Complex64, reflect.Complex128:
+		return compareComplex(a.Complex(), b.Complex())
+	case reflect.String:
+		return strings.Compare(a.String(), b.String())
+	case reflect.Pointer, reflect.Chan, reflect.UnsafePointer:
+		return cmp.Compare(a.Pointer(), b.Pointer())
+	case reflect.Array:
+		for i := 0; i < a.Len(); i++ {
+			if c := compareValues(a.Index(i), b.Index(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Struct:
+		for i := 0; i < a.NumField(); i++ {
+			if a.Type().Field(i).Name == "_" {
+				continue
+			}
+			if c := compareValues(a.Field(i), b.Field(i)); c != 0 {
+				return c
+			}
+		}
+		return 0
+	case reflect.Interface:
+		if a.IsNil() || b.IsNil() {
+			return cmp.Compare(boolToInt(!a.IsNil()), boolToInt(!b.IsNil()))
+		}
+		a, b = a.Elem(), b.Elem()
+		if a.Type() != b.Type() {
+			return strings.Compare(a.Type().String(), b.Type().String())
+		}
+		return compareValues(a, b)
+	}
+
+	panic("unsupported member type " + a.Type().String())
+}
+
+// boolToInt orders false before true
+func boolToInt(b bool) int {
+	if b {
+		return 1
+	}
+	return 0
+}
@@ -0,0 +1,86 @@
+package sset
+
+import "testing"
+
+func TestCompareMembers(t *testing.T) {
+	t.Run("CompareStructMembers", func(t *testing.T) {
+		type key struct {
+			Region string
+			ID     int64
+		}
+
+		compare := compareMembers[key]()
+		cases := []struct {
+			a, b     key
+			expected int
+		}{
+			{key{"eu", 1}, key{"eu", 1}, 0},
+			{key{"eu", 1}, key{"eu", 2}, -1},
+			{key{"us", 1}, key{"eu", 2}, 1},
+		}
+
+		for _, c := range cases {
+			if result := compare(c.a, c.b); result != c.expected {
+				t.Errorf("Comparison of %v and %v expected: %d, got: %d", c.a, c.b, c.expected, result)
+			}
+		}
+	})
+
+	t.Run("CompareArrayMembers", func(t *testing.T) {
+		compare := compareMembers[[4]byte]()
+		if compare([4]byte{1, 2, 3, 4}, [4]byte{1, 2, 4, 0}) != -1 || compare([4]byte{9}, [4]byte{1, 9}) != 1 {
+			t.Errorf("Arrays are not compared element by element")
+		}
+	})
+
+	t.Run("CompareInterfaceMembers", func(t *testing.T) {
+		compare := compareMembers[any]()
+		if compare(nil, 1) != -1 || compare(2, 1) != 1 || compare(1, "1") != -1 || compare("a", "a") != 0 {
+			t.Errorf("Interfaces are not compared by dynamic type and value")
+		}
+	})
+
+	t.Run("CompareOrderedMembersWithoutAllocation", func(t *testing.T) {
+		compareInt := compareMembers[int64]()
+		compareFloat := compareMembers[float64]()
+		compareBool := compareMembers[bool]()
+
+		a, b := int64(1)<<40, int64(1)<<41
+		allocs := testing.AllocsPerRun(100, func() {
+			if compareInt(a, b) != -1 || compareInt(b, a) != 1 || compareFloat(0.5, -0.5) != 1 || compareBool(false, true) != -1 {
+				t.Errorf("Ordered members are not compared naturally")
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("Comparison of ordered members expected no allocations, got: %v", allocs)
+		}
+	})
+
+	t.Run("CompareCompositeMembersWithoutAllocation", func(t *testing.T) {
+		type id string
+		type key struct {
+			Region string
+			_      int
+			ID     [16]byte
+			Next   *key
+		}
+
+		compareID := compareMembers[id]()
+		compareUUID := compareMembers[[16]byte]()
+		compareKey := compareMembers[key]()
+
+		a, b := key{Region: "eu", ID: [16]byte{1, 2}}, key{Region: "eu", ID: [16]byte{1, 3}}
+		allocs := testing.AllocsPerRun(100, func() {
+			if compareID("a", "b") != -1 || compareUUID([16]byte{15: 1}, [16]byte{}) != 1 || compareKey(a, b) != -1 || compareKey(b, b) != 0 {
+				t.Errorf("Composite members are not compared element by element")
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("Comparison of composite members expected no allocations, got: %v", allocs)
+		}
+
+		if compareKey(key{Next: &a}, key{Next: &a}) != 0 || compareKey(key{}, key{Next: &a}) != -1 {
+			t.Errorf("Pointer fields are not compared by address")
+		}
+	})
+}
@@ -0,0 +1,86 @@
+package sset
+
+import (
+	"cmp"
+
+	"github.com/parthdesai/sset/internals"
+)
+
+// Element is a member of sorted set along with its rank
+type Element[M comparable, S cmp.Ordered] struct {
+	Member M
+	Score  S
+}
+
+// Entry is a string member of sorted set along with its int rank
+type Entry = Element[string, int]
+
+// nodeEntries collects members of skiplist nodes along with their ranks
+func nodeEntries[M comparable, S cmp.Ordered](nodes []*internals.Node[S, M]) []Element[M, S] {
+	entries := make([]Element[M, S], len(nodes))
+	for i, node := range nodes {
+		entries[i] = Element[M, S]{Member: node.Value, Score: node.Key}
+	}
+	return entries
+}
+
+// GetRangeWithScores is same as GetRange, but also returns rank of members
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetRangeWithScores(scoreRange Interval[S]) ([]Element[M, S], error) {
+	if err := checkRange(scoreRange); err != nil {
+		return nil, err
+	}
+
+	s.rlock()
+	defer s.runlock()
+
+	return nodeEntries(s.skiplist.SearchRange(scoreRange)), nil
+}
+
+// GetRangeReverseWithScores is same as GetRangeReverse, but also returns
+// rank of members
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetRangeReverseWithScores(scoreRange Interval[S]) ([]Element[M, S], error) {
+	if err := checkRange(scoreRange); err != nil {
+		return nil, err
+	}
+
+	s.rlock()
+	defer s.runlock()
+
+	return nodeEntries(s.skiplist.SearchRangeReverse(scoreRange)), nil
+}
+
+// GetRangeLimitWithScores is same as GetRangeLimit, but also returns rank
+// of members
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetRangeLimitWithScores(scoreRange Interval[S], offset, count int) ([]Element[M, S], error) {
+	if err := checkRange(scoreRange); err != nil {
+		return nil, err
+	}
+
+	s.rlock()
+	defer s.runlock()
+
+	return nodeEntries(s.skiplist.SearchRangeLimit(scoreRange, offset, count)), nil
+}
+
+// GetByIndexRangeWithScores is same as GetByIndexRange, but also returns
+// rank of members
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetByIndexRangeWithScores(start, stop int) []Element[M, S] {
+	s.rlock()
+	defer s.runlock()
+
+	return nodeEntries(s.searchByIndex(start, stop, false))
+}
+
+// GetByIndexRangeReverseWithScores is same as GetByIndexRangeReverse, but
+// also returns rank of members
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetByIndexRangeReverseWithScores(start, stop int) []Element[M, S] {
+	s.rlock()
+	defer s.runlock()
+
+	return nodeEntries(s.searchByIndex(start, stop, true))
+}
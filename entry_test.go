@@ -0,0 +1,60 @@
+package sset
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestSortedSetWithScores(t *testing.T) {
+	s := SortedSet{}
+	s.Init()
+
+	s.Add("a", 1)
+	s.Add("b", 1)
+	s.Add("c", 2)
+	s.Add("d", 3)
+
+	t.Run("SortedSetGetRangeWithScores", func(t *testing.T) {
+		result := s.MustGetRangeWithScores(halfOpen(1, 3))
+		if fmt.Sprint(result) != "[{a 1} {b 1} {c 2}]" {
+			t.Errorf("Wrong entries returned from range: %v", result)
+			return
+		}
+
+		result = s.MustGetRangeReverseWithScores(halfOpen(1, 3))
+		if fmt.Sprint(result) != "[{c 2} {b 1} {a 1}]" {
+			t.Errorf("Wrong entries returned from reverse range: %v", result)
+			return
+		}
+
+		result = s.MustGetRangeLimitWithScores(halfOpen(1, 4), 1, 2)
+		if fmt.Sprint(result) != "[{b 1} {c 2}]" {
+			t.Errorf("Wrong entries returned from limited range: %v", result)
+			return
+		}
+
+		if len(s.MustGetRangeWithScores(halfOpen(4, 10))) != 0 {
+			t.Errorf("Result length should be zero for empty range")
+			return
+		}
+	})
+
+	t.Run("SortedSetGetByIndexRangeWithScores", func(t *testing.T) {
+		result := s.GetByIndexRangeWithScores(-2, -1)
+		if fmt.Sprint(result) != "[{c 2} {d 3}]" {
+			t.Errorf("Wrong entries returned from index range: %v", result)
+			return
+		}
+
+		result = s.GetByIndexRangeReverseWithScores(0, 1)
+		if fmt.Sprint(result) != "[{d 3} {c 2}]" {
+			t.Errorf("Wrong entries returned from reverse index range: %v", result)
+			return
+		}
+
+		if len(s.GetByIndexRangeWithScores(4, 10)) != 0 {
+			t.Errorf("Result length should be zero for empty index range")
+			return
+		}
+	})
+}
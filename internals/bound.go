@@ -0,0 +1,47 @@
+package internals
+
+import "cmp"
+
+// LexBound represents one end of lexicographical range of values
+type LexBound[V comparable] struct {
+	Value     V
+	Exclusive bool // Value itself is not part of the range
+	Unbounded bool // Range is open at this end, Value is ignored
+}
+
+// beforeMin reports whether value is ordered before range starting at b,
+// as compared by compare
+func (b LexBound[V]) beforeMin(value V, compare func(a, b V) int) bool {
+	return !b.Unbounded && (compare(value, b.Value) < 0 || (b.Exclusive && value == b.Value))
+}
+
+// withinMax reports whether value is ordered before end of range ending at
+// b, as compared by compare
+func (b LexBound[V]) withinMax(value V, compare func(a, b V) int) bool {
+	return b.Unbounded || compare(value, b.Value) < 0 || (!b.Exclusive && value == b.Value)
+}
+
+// KeyBound represents one end of range of keys
+type KeyBound[K cmp.Ordered] struct {
+	Key       K
+	Exclusive bool // Key itself is not part of the range
+	Unbounded bool // Range is open at this end, Key is ignored
+}
+
+// KeyRange represents range of keys in between Min and Max
+type KeyRange[K cmp.Ordered] struct {
+	Min KeyBound[K]
+	Max KeyBound[K]
+}
+
+// beforeMin reports whether key is ordered before range starting at b.
+// Every key is ordered before NaN, so that range starting at NaN is empty.
+func (b KeyBound[K]) beforeMin(key K) bool {
+	return !b.Unbounded && !(key > b.Key || (!b.Exclusive && key == b.Key))
+}
+
+// withinMax reports whether key is ordered before end of range ending at b
+// No key is ordered before NaN, so that range ending at NaN is empty.
+func (b KeyBound[K]) withinMax(key K) bool {
+	return b.Unbounded || key < b.Key || (!b.Exclusive && key == b.Key)
+}
@@ -0,0 +1,115 @@
+package internals
+
+import (
+	"math/rand"
+	randv2 "math/rand/v2"
+)
+
+// LevelGenerator decides level of each node inserted in skiplist.
+// Level must return level in between 0 and maxLevels - 1, where node present
+// at a level is also present at level above it with given probability.
+// Skiplist calls Level without locking, so generator must not be shared
+// between skiplists which are modified concurrently.
+type LevelGenerator interface {
+	Level(maxLevels int, probability float32) int
+}
+
+// randomLevel draws level from geometric distribution, using 16 most
+// significant bits returned by next for each level jump.
+func randomLevel(maxLevels int, probability float32, next func() uint64) int {
+	level := 0
+
+	// This enables 2^(maxLevel - level) distribution
+	for level < maxLevels-1 && float32(next()>>48) < probability*0xFFFF {
+		level++
+	}
+	return level
+}
+
+// PCGLevelGenerator draws levels from PCG generator of math/rand/v2, so that
+// same seed produces same levels.
+type PCGLevelGenerator struct {
+	random *randv2.Rand
+}
+
+// NewPCGLevelGenerator returns PCG level generator seeded with seed1 and seed2
+func NewPCGLevelGenerator(seed1, seed2 uint64) *PCGLevelGenerator {
+	return &PCGLevelGenerator{random: randv2.New(randv2.NewPCG(seed1, seed2))}
+}
+
+// Level returns next random level
+func (g *PCGLevelGenerator) Level(maxLevels int, probability float32) int {
+	return randomLevel(maxLevels, probability, g.random.Uint64)
+}
+
+// XorshiftLevelGenerator draws levels from xorshift64* generator. It is the
+// fastest generator, and holds all its state in itself, so that skiplists
+// using different generators never contend on a lock.
+type XorshiftLevelGenerator struct {
+	state uint64
+}
+
+// NewXorshiftLevelGenerator returns xorshift level generator seeded with
+// seed. Zero seed, which xorshift can not start from, is replaced with a
+// fixed non zero seed.
+func NewXorshiftLevelGenerator(seed uint64) *XorshiftLevelGenerator {
+	if seed == 0 {
+		seed = 0x9E3779B97F4A7C15
+	}
+	return &XorshiftLevelGenerator{state: seed}
+}
+
+// Level returns next random level
+func (g *XorshiftLevelGenerator) Level(maxLevels int, probability float32) int {
+	return randomLevel(maxLevels, probability, g.next)
+}
+
+// next advances xorshift64* state and returns next random number
+func (g *XorshiftLevelGenerator) next() uint64 {
+	g.state ^= g.state >> 12
+	g.state ^= g.state << 25
+	g.state ^= g.state >> 27
+	return g.state * 2685821657736338717
+}
+
+// SequenceLevelGenerator returns given levels in order, starting over after
+// the last one. Probability is ignored, and levels are capped to
+// maxLevels - 1. It is meant for tests which need exact shape of skiplist.
+type SequenceLevelGenerator struct {
+	levels []int
+	next   int
+}
+
+// NewSequenceLevelGenerator returns level generator repeating levels.
+// No levels always yield level 0.
+func NewSequenceLevelGenerator(levels ...int) *SequenceLevelGenerator {
+	return &SequenceLevelGenerator{levels: levels}
+}
+
+// Level returns next level of the sequence
+func (g *SequenceLevelGenerator) Level(maxLevels int, probability float32) int {
+	if len(g.levels) == 0 {
+		return 0
+	}
+
+	level := g.levels[g.next]
+	g.next = (g.next + 1) % len(g.levels)
+	return max(0, min(level, maxLevels-1))
+}
+
+// RandLevelGenerator draws levels from source of math/rand
+type RandLevelGenerator struct {
+	random *rand.Rand
+}
+
+// NewRandLevelGenerator returns level generator using source
+func NewRandLevelGenerator(source rand.Source) *RandLevelGenerator {
+	return &RandLevelGenerator{random: rand.New(source)}
+}
+
+// Level returns next random level
+func (g *RandLevelGenerator) Level(maxLevels int, probability float32) int {
+	return randomLevel(maxLevels, probability, func() uint64 {
+		return g.random.Uint64()
+	})
+}
@@ -0,0 +1,82 @@
+package internals
+
+import (
+	"math/rand"
+	"slices"
+	"strings"
+	"testing"
+)
+
+func TestLevelGenerator(t *testing.T) {
+	t.Run("SequenceLevels", func(t *testing.T) {
+		g := NewSequenceLevelGenerator(0, 2, 9, -1)
+
+		levels := []int{}
+		for i := 0; i < 6; i++ {
+			levels = append(levels, g.Level(4, 0.5))
+		}
+
+		if !slices.Equal(levels, []int{0, 2, 3, 0, 0, 2}) {
+			t.Errorf("Wrong levels returned by sequence: %v", levels)
+			return
+		}
+
+		if NewSequenceLevelGenerator().Level(4, 0.5) != 0 {
+			t.Errorf("Empty sequence should return level 0")
+			return
+		}
+	})
+
+	t.Run("SeededLevelsAreReproducible", func(t *testing.T) {
+		generators := map[string]func() LevelGenerator{
+			"PCG":      func() LevelGenerator { return NewPCGLevelGenerator(1, 2) },
+			"Xorshift": func() LevelGenerator { return NewXorshiftLevelGenerator(0) },
+			"Rand":     func() LevelGenerator { return NewRandLevelGenerator(rand.NewSource(3)) },
+		}
+
+		for name, newGenerator := range generators {
+			first, second := newGenerator(), newGenerator()
+			for i := 0; i < 100; i++ {
+				if first.Level(32, 0.5) != second.Level(32, 0.5) {
+					t.Errorf("%s generators with same seed returned different levels", name)
+					return
+				}
+			}
+		}
+	})
+
+	t.Run("RandomLevelsDistribution", func(t *testing.T) {
+		for _, g := range []LevelGenerator{NewPCGLevelGenerator(7, 7), NewXorshiftLevelGenerator(7)} {
+			counts := make([]int, 32)
+			for i := 0; i < 100000; i++ {
+				level := g.Level(32, 0.25)
+				if level < 0 || level >= 32 {
+					t.Errorf("Level out of range: %d", level)
+					return
+				}
+				counts[level]++
+			}
+
+			// Roughly 3/4 of nodes should stay at level 0, and 3/16 at level 1
+			if counts[0] < 74000 || counts[0] > 76000 || counts[1] < 18000 || counts[1] > 19500 {
+				t.Errorf("Levels are not distributed geometrically: %v", counts[:4])
+				return
+			}
+		}
+	})
+
+	t.Run("SkipListShapeFromSequence", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(4, 0.5, NewSequenceLevelGenerator(0, 2, 1, 0, 3), strings.Compare)
+
+		for i := 1; i <= 5; i++ {
+			s.Insert(i, "")
+		}
+
+		expected := []string{"0:1,2,3,4,5,", "1:2,3,5,", "2:2,5,", "3:5,"}
+		if out := s.DebugPrint(); !slices.Equal(out, expected) {
+			t.Errorf("Debug Print expected: %v, got: %v", expected, out)
+			return
+		}
+	})
+}
@@ -1,21 +1,39 @@
 package internals
 
 import (
-	"math/rand"
+	"cmp"
+	"errors"
+	"fmt"
+	"iter"
+	"math/rand/v2"
+	"slices"
 	"strconv"
 	"strings"
 )
 
-// Node represents individual node in skiplist with unique key
+// Node represents individual node in skiplist with unique (key, value) pair
+// Nodes are ordered by key, and nodes sharing same key are ordered by value,
+// as compared by compareValues function of skiplist.
 // Each node have at max maxLevels of pointer to next node
-// Values is map due to efficiency of checking whether value exists in particular
-// node or not.
-type Node struct {
-	Next   []*Node
-	Key    int
-	Values map[string]bool
+// Span at each level holds number of nodes after this node up to and
+// including next node at that level. It is only meaningful when next node
+// at that level is not nil.
+// Prev points to previous node at level 0, it is nil for the first node.
+type Node[K cmp.Ordered, V comparable] struct {
+	Next  []*Node[K, V]
+	Span  []int
+	Prev  *Node[K, V]
+	Key   K
+	Value V
 }
 
+// ErrInvalidLevels is returned by Init when maximum number of levels is
+// less than 1
+var ErrInvalidLevels = errors.New("internals: maximum number of levels must be greater than or equal to 1")
+
+// ErrNilCompare is returned by Init when compareValues is nil
+var ErrNilCompare = errors.New("internals: compareValues must not be nil")
+
 // SkipList is one of underlying data structure of sorted set
 // It has very high probability O(log n) insert, lookup and delete
 // It consists of n number of node, each one with maxLevels pointer
@@ -23,24 +41,31 @@ type Node struct {
 // Due to power-alike arrnagement of node (from higher level to lower level),
 // Each level can act as express way for level below it, thus enabling O(log n)
 // probability.
-type SkipList struct {
-	header               *Node
+// Number of nodes and number of distinct keys are tracked on every insert and
+// delete, so that they can be read in O(1).
+// Header node is a sentinel, its key and value are never compared.
+type SkipList[K cmp.Ordered, V comparable] struct {
+	header               *Node[K, V]
+	tail                 *Node[K, V]
 	maxLevels            int
 	currentLevel         int
 	levelJumpProbability float32
-	minKey               int
+	levels               LevelGenerator
+	compareValues        func(a, b V) int
+	length               int
+	distinctKeys         int
 }
 
 // DebugPrint returns array of string, that gives you information
 // about node position and levels
-func (s *SkipList) DebugPrint() []string {
+func (s *SkipList[K, V]) DebugPrint() []string {
 	debugData := make([]string, s.currentLevel+1)
 	for i := 0; i <= s.currentLevel; i++ {
 		strBuilder := strings.Builder{}
 		strBuilder.WriteString(strconv.Itoa(i) + ":")
 		current := s.header.Next[i]
 		for current != nil {
-			strBuilder.WriteString(strconv.Itoa(current.Key) + ",")
+			strBuilder.WriteString(fmt.Sprint(current.Key) + ",")
 			current = current.Next[i]
 		}
 		debugData[i] = strBuilder.String()
@@ -48,85 +73,235 @@ func (s *SkipList) DebugPrint() []string {
 	return debugData
 }
 
-func (s *SkipList) createNewNode(key int, initialValues map[string]bool) *Node {
-	n := &Node{}
-	n.Next = make([]*Node, s.maxLevels)
+func (s *SkipList[K, V]) createNewNode(key K, value V) *Node[K, V] {
+	n := &Node[K, V]{}
+	n.Next = make([]*Node[K, V], s.maxLevels)
+	n.Span = make([]int, s.maxLevels)
 	n.Key = key
-	n.Values = initialValues
+	n.Value = value
 	return n
 }
 
+// less reports whether node is ordered before (key, value) pair
+func (s *SkipList[K, V]) less(n *Node[K, V], key K, value V) bool {
+	return n.Key < key || (n.Key == key && s.compareValues(n.Value, value) < 0)
+}
+
 // Init Initiates skip list, with maximum number of level supported
 // levelJumpProbability indicates the probability by which node in level i, can
 // be also present at level i - 1
-// minKey restricts key space to [minKey, MAX_INT)
-func (s *SkipList) Init(maxLevels int, levelJumpProbability float32, minKey int) {
+// levels decides level of each inserted node, nil uses xorshift generator
+// with random seed.
+// compareValues orders values of nodes sharing same key, returning negative
+// number, zero or positive number just like cmp.Compare.
+// It returns ErrInvalidLevels if maxLevels is less than 1, and ErrNilCompare
+// if compareValues is nil.
+func (s *SkipList[K, V]) Init(maxLevels int, levelJumpProbability float32, levels LevelGenerator, compareValues func(a, b V) int) error {
 
 	if maxLevels < 1 {
-		panic("Maximum number of levels should be greater than or equal to 1")
+		return ErrInvalidLevels
+	}
+
+	if compareValues == nil {
+		return ErrNilCompare
 	}
 
 	s.currentLevel = -1
 	s.maxLevels = maxLevels
 	s.levelJumpProbability = levelJumpProbability
-	s.minKey = minKey
-	s.header = s.createNewNode(s.minKey-1, nil)
+	s.levels = levels
+	if levels == nil {
+		s.levels = NewXorshiftLevelGenerator(rand.Uint64())
+	}
+	s.compareValues = compareValues
+	s.header = s.createNewNode(*new(K), *new(V))
+	s.tail = nil
+	s.length = 0
+	s.distinctKeys = 0
+	return nil
 }
 
-func (s *SkipList) generateRandLevel() int {
-	level := 0
+// Len returns number of nodes in skiplist
+// Time complexity: O(1)
+func (s *SkipList[K, V]) Len() int {
+	return s.length
+}
 
-	// This enables 2^(maxLevel - level) distribution
-	for (float32((rand.Int() & 0xFFFF)) < (s.levelJumpProbability * 0xFFFF)) && level < (s.maxLevels-1) {
-		level++
-	}
-	return level
+// DistinctKeys returns number of distinct keys in skiplist
+// Time complexity: O(1)
+func (s *SkipList[K, V]) DistinctKeys() int {
+	return s.distinctKeys
 }
 
-// DeleteOrModify either deletes entire node OR deletes one of the value
-// This is determined by function reference passed to it.
-// Time complexity: O(log n)
-func (s *SkipList) DeleteOrModify(key int,
-	modifyOrDelete func(map[string]bool) (bool, map[string]bool)) bool {
+// Levels returns number of levels currently in use
+// Time complexity: O(1)
+func (s *SkipList[K, V]) Levels() int {
+	return s.currentLevel + 1
+}
 
-	if key < s.minKey {
-		panic("Key must be greater than or equal to minKey")
-	}
+// sharesKey reports whether any neighbour of node at level 0 has same key
+func (n *Node[K, V]) sharesKey() bool {
+	return (n.Prev != nil && n.Prev.Key == n.Key) || (n.Next[0] != nil && n.Next[0].Key == n.Key)
+}
+
+func (s *SkipList[K, V]) generateRandLevel() int {
+	return s.levels.Level(s.maxLevels, s.levelJumpProbability)
+}
+
+// findPredecessors finds, at each level, last node ordered before given
+// (key, value) pair, along with number of nodes up to and including it.
+// Time complexity: O(log n)
+func (s *SkipList[K, V]) findPredecessors(key K, value V) ([]*Node[K, V], []int) {
+	return s.findPredecessorsFunc(func(n *Node[K, V]) bool { return s.less(n, key, value) })
+}
 
+// findPredecessorsFunc finds, at each level, last node for which before
+// returns true, along with number of nodes up to and including it.
+// before must hold for a prefix of nodes.
+// Time complexity: O(log n)
+func (s *SkipList[K, V]) findPredecessorsFunc(before func(*Node[K, V]) bool) ([]*Node[K, V], []int) {
 	current := s.header
-	updateArray := make([]*Node, s.maxLevels)
-	var nodeToDelete *Node
+	traversed := 0
+	updateArray := make([]*Node[K, V], s.maxLevels)
+	rankArray := make([]int, s.maxLevels)
 
 	// O(log n) time with very high probability
 	for i := s.currentLevel; i >= 0; i-- {
-		for current.Next[i] != nil && current.Next[i].Key < key {
+		for current.Next[i] != nil && before(current.Next[i]) {
+			traversed += current.Span[i]
 			current = current.Next[i]
 		}
+		updateArray[i] = current
+		rankArray[i] = traversed
+	}
 
-		if current.Next[i] != nil && current.Next[i].Key == key {
-			updateArray[i] = current
-			nodeToDelete = current.Next[i]
+	// List is empty, header is predecessor at level 0
+	if s.currentLevel < 0 {
+		updateArray[0] = s.header
+	}
+
+	return updateArray, rankArray
+}
+
+// findLast finds last node for which before returns true, along with number
+// of nodes up to and including it. before must hold for a prefix of nodes.
+// Time complexity: O(log n)
+func (s *SkipList[K, V]) findLast(before func(*Node[K, V]) bool) (*Node[K, V], int) {
+	current := s.header
+	traversed := 0
+
+	for i := s.currentLevel; i >= 0; i-- {
+		for current.Next[i] != nil && before(current.Next[i]) {
+			traversed += current.Span[i]
+			current = current.Next[i]
 		}
 	}
 
-	if nodeToDelete == nil {
+	return current, traversed
+}
+
+// Insert adds new node for (key, value) pair to skiplist
+// Returns false if pair is already present, or key is NaN, which can not be
+// ordered.
+// Time complexity: O(log n)
+func (s *SkipList[K, V]) Insert(key K, value V) bool {
+
+	if key != key {
 		return false
 	}
 
-	var modifiedValue map[string]bool
-	needToDelete := true
+	updateArray, rankArray := s.findPredecessors(key, value)
 
-	if modifyOrDelete != nil {
-		needToDelete, modifiedValue = modifyOrDelete(nodeToDelete.Values)
+	/** Get next node at Level 0, this is the node, which can have one of three values:
+	 ** 1) Node ordered after (key, value)
+	 ** 2) Node equal to (key, value)
+	 ** 3) Nil **/
+	current := updateArray[0].Next[0]
+	if current != nil && current.Key == key && current.Value == value {
+		return false
 	}
 
-	if !needToDelete {
-		nodeToDelete.Values = modifiedValue
+	levels := s.generateRandLevel()
+	node := s.createNewNode(key, value)
+
+	if s.currentLevel < levels {
+		for i := s.currentLevel + 1; i <= levels; i++ {
+			updateArray[i] = s.header
+			rankArray[i] = 0
+		}
+
+		s.currentLevel = levels
+	}
+
+	for i := 0; i <= levels; i++ {
+		node.Next[i] = updateArray[i].Next[i]
+		updateArray[i].Next[i] = node
+
+		// Nodes between predecessor at level i and predecessor at level 0
+		// are skipped by this level.
+		skipped := rankArray[0] - rankArray[i]
+		node.Span[i] = updateArray[i].Span[i] - skipped
+		updateArray[i].Span[i] = skipped + 1
+	}
+
+	for i := levels + 1; i <= s.currentLevel; i++ {
+		updateArray[i].Span[i]++
+	}
+
+	if updateArray[0] != s.header {
+		node.Prev = updateArray[0]
+	}
+
+	if node.Next[0] != nil {
+		node.Next[0].Prev = node
+	} else {
+		s.tail = node
+	}
+
+	s.length++
+	if !node.sharesKey() {
+		s.distinctKeys++
+	}
+
+	return true
+}
+
+// Delete deletes node of (key, value) pair from skiplist
+// Returns false if pair is not present.
+// Time complexity: O(log n)
+func (s *SkipList[K, V]) Delete(key K, value V) bool {
+
+	updateArray, _ := s.findPredecessors(key, value)
+	nodeToDelete := updateArray[0].Next[0]
+
+	if nodeToDelete == nil || nodeToDelete.Key != key || nodeToDelete.Value != value {
 		return false
 	}
 
-	for i := 0; i <= s.currentLevel && updateArray[i] != nil; i++ {
-		updateArray[i].Next[i] = nodeToDelete.Next[i]
+	s.unlinkNode(nodeToDelete, updateArray)
+	return true
+}
+
+// unlinkNode unlinks node from skiplist, given its predecessor at each level
+func (s *SkipList[K, V]) unlinkNode(nodeToDelete *Node[K, V], updateArray []*Node[K, V]) {
+	s.length--
+	if !nodeToDelete.sharesKey() {
+		s.distinctKeys--
+	}
+
+	if nodeToDelete.Next[0] != nil {
+		nodeToDelete.Next[0].Prev = nodeToDelete.Prev
+	} else {
+		s.tail = nodeToDelete.Prev
+	}
+
+	for i := 0; i <= s.currentLevel; i++ {
+		if updateArray[i].Next[i] == nodeToDelete {
+			updateArray[i].Span[i] += nodeToDelete.Span[i] - 1
+			updateArray[i].Next[i] = nodeToDelete.Next[i]
+		} else {
+			updateArray[i].Span[i]--
+		}
 	}
 
 	newCurrentLevel := s.currentLevel
@@ -136,139 +311,303 @@ func (s *SkipList) DeleteOrModify(key int,
 		}
 	}
 	s.currentLevel = newCurrentLevel
+}
 
-	return true
+// deleteRun deletes consecutive nodes following given predecessors, as long
+// as within returns true for them. Predecessors stay same while nodes after
+// them are deleted, so entire run is spliced out in a single pass.
+func (s *SkipList[K, V]) deleteRun(updateArray []*Node[K, V], within func(*Node[K, V]) bool) []*Node[K, V] {
+	var deleted []*Node[K, V]
+
+	for current := updateArray[0].Next[0]; current != nil && within(current); {
+		next := current.Next[0]
+		s.unlinkNode(current, updateArray)
+		deleted = append(deleted, current)
+		current = next
+	}
+
+	return deleted
 }
 
-// SearchRange searches skiplist and finds node which has key less than
-// keyMax, and greater than or equal to keyMin
-// Time complexity: O((log n) + r) where r is number of elements in the range
-func (s *SkipList) SearchRange(keyMin, keyMax int) []map[string]bool {
+// DeleteRange deletes nodes which have key in between Min and Max of
+// keyRange, and returns them in ascending order.
+// Time complexity: O((log n) + r) where r is number of nodes deleted
+func (s *SkipList[K, V]) DeleteRange(keyRange KeyRange[K]) []*Node[K, V] {
+	updateArray, _ := s.findPredecessorsFunc(func(n *Node[K, V]) bool { return keyRange.Min.beforeMin(n.Key) })
+	return s.deleteRun(updateArray, func(n *Node[K, V]) bool { return keyRange.Max.withinMax(n.Key) })
+}
 
-	if keyMin < s.minKey || keyMax < s.minKey {
-		panic("keyMin and keyMax must be greater than or equal to minKey")
-	}
+// DeleteLexRange deletes nodes with value in between min and max, with same
+// guarantees as SearchLexRange, and returns them in ascending order.
+// Time complexity: O((log n) + r) where r is number of nodes deleted
+func (s *SkipList[K, V]) DeleteLexRange(min, max LexBound[V]) []*Node[K, V] {
+	updateArray, _ := s.findPredecessorsFunc(func(n *Node[K, V]) bool { return min.beforeMin(n.Value, s.compareValues) })
+	return s.deleteRun(updateArray, func(n *Node[K, V]) bool { return max.withinMax(n.Value, s.compareValues) })
+}
 
-	if keyMin >= keyMax {
-		panic("keyMin must be less than keyMax")
+// DeleteByIndex deletes nodes with 0-based index in between start and stop,
+// both inclusive, and returns them in ascending order. Indexes are clamped
+// just like SearchByIndex.
+// Time complexity: O((log n) + r) where r is number of nodes deleted
+func (s *SkipList[K, V]) DeleteByIndex(start, stop int) []*Node[K, V] {
+
+	start, stop, ok := s.clampIndexRange(start, stop)
+	if !ok {
+		return nil
 	}
 
-	var searchResult []map[string]bool
-	current := s.header
+	first := s.nodeAt(start)
+	remaining := stop - start + 1
+	updateArray, _ := s.findPredecessors(first.Key, first.Value)
+	return s.deleteRun(updateArray, func(*Node[K, V]) bool {
+		remaining--
+		return remaining >= 0
+	})
+}
 
-	for i := s.currentLevel; i >= 0; i-- {
-		for current.Next[i] != nil && current.Next[i].Key < keyMin {
-			current = current.Next[i]
-		}
-	}
+// PopFirst deletes at most count nodes from the start of skiplist and
+// returns them in ascending order.
+// Time complexity: O(r) where r is number of nodes deleted
+func (s *SkipList[K, V]) PopFirst(count int) []*Node[K, V] {
+	var popped []*Node[K, V]
 
-	/** Get next node at Level 0, this is the node, which can have one of three values:
-	 ** 1) Node with key greater than current key
-	 ** 2) Node with key equal to current key
-	 ** 3) Nil **/
-	current = current.Next[0]
+	// Header is predecessor of the first node at every level
+	updateArray := make([]*Node[K, V], s.maxLevels)
+	for i := range updateArray {
+		updateArray[i] = s.header
+	}
 
-	if current == nil {
-		return searchResult
+	for ; count > 0 && s.header.Next[0] != nil; count-- {
+		node := s.header.Next[0]
+		s.unlinkNode(node, updateArray)
+		popped = append(popped, node)
 	}
 
-	numberOfNodes := 0
-	startNode := current
-	for current != nil && current.Key < keyMax {
-		numberOfNodes++
-		current = current.Next[0]
+	return popped
+}
+
+// PopLast deletes at most count nodes from the end of skiplist and returns
+// them in descending order.
+// Time complexity: O((log n) + r) where r is number of nodes deleted
+func (s *SkipList[K, V]) PopLast(count int) []*Node[K, V] {
+	if count <= 0 || s.length == 0 {
+		return nil
 	}
+	count = min(count, s.length)
+
+	// Find first node to pop once, then splice out entire run up to the end
+	first := s.nodeAt(s.length - count)
+	updateArray, _ := s.findPredecessors(first.Key, first.Value)
+	popped := s.deleteRun(updateArray, func(*Node[K, V]) bool { return true })
+
+	slices.Reverse(popped)
+	return popped
+}
+
+// Search finds all nodes with given key, ordered by value
+// Time complexity: O((log n) + r) where r is number of nodes with given key
+func (s *SkipList[K, V]) Search(key K) []*Node[K, V] {
 
-	searchResult = make([]map[string]bool, numberOfNodes)
-	for i, node := 0, startNode; i < numberOfNodes; i, node = i+1, node.Next[0] {
-		searchResult[i] = node.Values
+	var searchResult []*Node[K, V]
+
+	current, _ := s.findLast(func(n *Node[K, V]) bool { return n.Key < key })
+	for current = current.Next[0]; current != nil && current.Key == key; current = current.Next[0] {
+		searchResult = append(searchResult, current)
 	}
 
 	return searchResult
 }
 
-// SearchOrModify searches skiplist for element
-// You can also supply a function, that can be used to modifiy searched values
-// Time complexity: O(log n)
-func (s *SkipList) SearchOrModify(key int,
-	modifier func(map[string]bool) map[string]bool) map[string]bool {
+// SearchRange searches skiplist and finds nodes which have key in between
+// Min and Max of keyRange. Empty or inverted range yields no nodes.
+// Time complexity: O((log n) + r) where r is number of elements in the range
+func (s *SkipList[K, V]) SearchRange(keyRange KeyRange[K]) []*Node[K, V] {
+	var searchResult []*Node[K, V]
+	for node := range s.Ascend(keyRange) {
+		searchResult = append(searchResult, node)
+	}
+	return searchResult
+}
 
-	if key < s.minKey {
-		panic("key must be greater than or equal to minKey")
+// SearchRangeReverse searches skiplist and finds nodes which have key in
+// between Min and Max of keyRange, in descending order.
+// Time complexity: O((log n) + r) where r is number of elements in the range
+func (s *SkipList[K, V]) SearchRangeReverse(keyRange KeyRange[K]) []*Node[K, V] {
+	var searchResult []*Node[K, V]
+	for node := range s.Descend(keyRange) {
+		searchResult = append(searchResult, node)
 	}
+	return searchResult
+}
 
-	var searchResult map[string]bool
-	current := s.header
+// Ascend returns iterator over nodes which have key in between Min and Max
+// of keyRange, in ascending order. Skiplist must not be modified while
+// iterating.
+// Time complexity: O(log n) to start, O(1) per node
+func (s *SkipList[K, V]) Ascend(keyRange KeyRange[K]) iter.Seq[*Node[K, V]] {
+	return func(yield func(*Node[K, V]) bool) {
+		current, _ := s.findLast(func(n *Node[K, V]) bool { return keyRange.Min.beforeMin(n.Key) })
+		for current = current.Next[0]; current != nil && keyRange.Max.withinMax(current.Key); current = current.Next[0] {
+			if !yield(current) {
+				return
+			}
+		}
+	}
+}
 
-	// O(log n) time with very high probability
-	for i := s.currentLevel; i >= 0; i-- {
-		for current.Next[i] != nil && current.Next[i].Key < key {
-			current = current.Next[i]
+// Descend returns iterator over nodes which have key in between Min and Max
+// of keyRange, in descending order. Skiplist must not be modified while
+// iterating.
+// Time complexity: O(log n) to start, O(1) per node
+func (s *SkipList[K, V]) Descend(keyRange KeyRange[K]) iter.Seq[*Node[K, V]] {
+	return func(yield func(*Node[K, V]) bool) {
+		// Last node within Max of the range, walk backward from there
+		current, _ := s.findLast(func(n *Node[K, V]) bool { return keyRange.Max.withinMax(n.Key) })
+		if current == s.header {
+			return
 		}
 
-		if current.Next[i] != nil && current.Next[i].Key == key {
-			// Modifier is not nil, then we can invoke it and modify functionality
-			if modifier != nil {
-				current.Next[i].Values = modifier(current.Next[i].Values)
+		for ; current != nil && !keyRange.Min.beforeMin(current.Key); current = current.Prev {
+			if !yield(current) {
+				return
 			}
-			searchResult = current.Next[i].Values
-			break
 		}
 	}
+}
+
+// CountRange counts nodes which have key in between Min and Max of keyRange
+// Time complexity: O(log n)
+func (s *SkipList[K, V]) CountRange(keyRange KeyRange[K]) int {
+	_, start := s.findLast(func(n *Node[K, V]) bool { return keyRange.Min.beforeMin(n.Key) })
+	_, end := s.findLast(func(n *Node[K, V]) bool { return keyRange.Max.withinMax(n.Key) })
+
+	if end < start {
+		return 0
+	}
+	return end - start
+}
+
+// SearchLexRange finds nodes with value in between min and max, ordered by
+// value. Ordering by value is only guaranteed when all nodes share same key.
+// Time complexity: O((log n) + r) where r is number of elements in the range
+func (s *SkipList[K, V]) SearchLexRange(min, max LexBound[V]) []*Node[K, V] {
+	var searchResult []*Node[K, V]
+
+	current, _ := s.findLast(func(n *Node[K, V]) bool { return min.beforeMin(n.Value, s.compareValues) })
+	for current = current.Next[0]; current != nil && max.withinMax(current.Value, s.compareValues); current = current.Next[0] {
+		searchResult = append(searchResult, current)
+	}
 
 	return searchResult
 }
 
-// AddOrModify adds new node to skiplist if it does not exists,
-// if it is you can supply modifier function that can be used to modify value
-// of existing node
+// LexCount counts nodes with value in between min and max, with same
+// guarantees as SearchLexRange.
+// Time complexity: O(log n)
+func (s *SkipList[K, V]) LexCount(min, max LexBound[V]) int {
+	_, start := s.findLast(func(n *Node[K, V]) bool { return min.beforeMin(n.Value, s.compareValues) })
+	_, end := s.findLast(func(n *Node[K, V]) bool { return max.withinMax(n.Value, s.compareValues) })
+
+	if end < start {
+		return 0
+	}
+	return end - start
+}
+
+// Rank returns number of nodes ordered before given (key, value) pair, which
+// is 0-based index of the pair if it is present.
 // Time complexity: O(log n)
-func (s *SkipList) AddOrModify(key int, value map[string]bool,
-	modifier func(map[string]bool) map[string]bool) {
+func (s *SkipList[K, V]) Rank(key K, value V) int {
+
+	_, rankArray := s.findPredecessors(key, value)
+	return rankArray[0]
+}
+
+// SearchByIndex finds nodes with 0-based index in between start and stop,
+// both inclusive. Negative start is treated as 0, and stop past the end as
+// the last index. Range left empty, e.g. when start is greater than stop,
+// finds no nodes.
+// Time complexity: O((log n) + r) where r is number of nodes in the range
+func (s *SkipList[K, V]) SearchByIndex(start, stop int) []*Node[K, V] {
 
-	if key < s.minKey {
-		panic("key must be greater than or equal to minKey")
+	var searchResult []*Node[K, V]
+
+	start, stop, ok := s.clampIndexRange(start, stop)
+	if !ok {
+		return searchResult
 	}
 
+	for current, index := s.nodeAt(start), start; current != nil && index <= stop; current, index = current.Next[0], index+1 {
+		searchResult = append(searchResult, current)
+	}
+
+	return searchResult
+}
+
+// clampIndexRange clamps start and stop to indexes of skiplist, reporting
+// false if range is empty
+func (s *SkipList[K, V]) clampIndexRange(start, stop int) (int, int, bool) {
+	start, stop = max(start, 0), min(stop, s.length-1)
+	return start, stop, start <= stop
+}
+
+// nodeAt finds node with given 0-based index, nil if index is past the end
+// Time complexity: O(log n)
+func (s *SkipList[K, V]) nodeAt(index int) *Node[K, V] {
 	current := s.header
-	updateArray := make([]*Node, s.maxLevels)
+	traversed := 0
+
 	for i := s.currentLevel; i >= 0; i-- {
-		for current.Next[i] != nil && current.Next[i].Key < key {
+		for current.Next[i] != nil && traversed+current.Span[i] <= index {
+			traversed += current.Span[i]
 			current = current.Next[i]
 		}
-		updateArray[i] = current
 	}
 
-	/** Get next node at Level 0, this is the node, which can have one of three values:
-	 ** 1) Node with key greater than current key
-	 ** 2) Node with key equal to current key
-	 ** 3) Nil **/
-	current = current.Next[0]
+	// Node following current at level 0 has given index
+	return current.Next[0]
+}
 
-	/** Reached end of list, or current is bigger than key **/
-	if current == nil || current.Key != key {
-		levels := s.generateRandLevel()
-		node := s.createNewNode(key, value)
+// SearchRangeLimit searches skiplist just like SearchRange, but skips first
+// offset nodes of the range and returns at most count nodes.
+// Negative count returns all nodes after offset.
+// Time complexity: O((log n) + r) where r is number of elements returned
+func (s *SkipList[K, V]) SearchRangeLimit(keyRange KeyRange[K], offset, count int) []*Node[K, V] {
+	var searchResult []*Node[K, V]
 
-		if s.currentLevel < levels {
-			for i := s.currentLevel + 1; i <= levels; i++ {
-				updateArray[i] = s.header
-			}
+	// Jump directly to offset using spans, instead of walking the range
+	_, start := s.findLast(func(n *Node[K, V]) bool { return keyRange.Min.beforeMin(n.Key) })
 
-			s.currentLevel = levels
-		}
+	// Offset past the end is compared before adding, so huge offset does not
+	// overflow into index of a node before the range
+	if offset < 0 || offset >= s.length-start {
+		return searchResult
+	}
+	current := s.nodeAt(start + offset)
 
-		for i := 0; i <= levels; i++ {
-			node.Next[i] = updateArray[i].Next[i]
-			updateArray[i].Next[i] = node
-		}
+	for ; current != nil && count != 0 && keyRange.Max.withinMax(current.Key); current = current.Next[0] {
+		searchResult = append(searchResult, current)
+		count--
+	}
 
-	} else {
-		if modifier != nil {
-			current.Values = modifier(current.Values)
-		} else {
-			current.Values = value
-		}
+	return searchResult
+}
+
+// SearchRangeAfter searches skiplist just like SearchRange, but only returns
+// nodes ordered after given (key, value) pair, at most count of them.
+// Negative count returns all such nodes.
+// Time complexity: O((log n) + r) where r is number of elements returned
+func (s *SkipList[K, V]) SearchRangeAfter(keyRange KeyRange[K], key K, value V, count int) []*Node[K, V] {
+	var searchResult []*Node[K, V]
+
+	current, _ := s.findLast(func(n *Node[K, V]) bool {
+		return keyRange.Min.beforeMin(n.Key) || s.less(n, key, value) || (n.Key == key && n.Value == value)
+	})
+
+	for current = current.Next[0]; current != nil && count != 0 && keyRange.Max.withinMax(current.Key); current = current.Next[0] {
+		searchResult = append(searchResult, current)
+		count--
 	}
 
+	return searchResult
 }
@@ -1,32 +1,58 @@
 package internals
 
 import (
+	"cmp"
+	"math"
 	"strconv"
+	"strings"
 	"testing"
 )
 
+// nodeValues collects values of nodes, for easier comparison
+func nodeValues(nodes []*Node[int, string]) []string {
+	values := make([]string, len(nodes))
+	for i, node := range nodes {
+		values[i] = node.Value
+	}
+	return values
+}
+
+// halfOpen returns range of keys including keyMin and excluding keyMax
+func halfOpen(keyMin, keyMax int) KeyRange[int] {
+	return KeyRange[int]{Min: KeyBound[int]{Key: keyMin}, Max: KeyBound[int]{Key: keyMax, Exclusive: true}}
+}
+
 func TestSkipListInitialization(t *testing.T) {
-	t.Run("PanicForInvalidLevels", func(t *testing.T) {
-		defer func() {
-			if r := recover(); r == nil {
-				t.Errorf("Did not panic, even when level passed was less than 1")
-				return
-			}
-		}()
+	t.Run("ErrorForInvalidLevels", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		if err := s.Init(0, 0.5, nil, strings.Compare); err != ErrInvalidLevels {
+			t.Errorf("Init with level less than 1 expected error: %v, got: %v", ErrInvalidLevels, err)
+			return
+		}
+
+		if err := s.Init(1, 0.5, nil, strings.Compare); err != nil {
+			t.Errorf("Init with valid level returned error: %v", err)
+			return
+		}
+	})
 
-		s := SkipList{}
-		s.Init(0, 0.5, 0)
+	t.Run("ErrorForNilCompare", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		if err := s.Init(1, 0.5, nil, nil); err != ErrNilCompare {
+			t.Errorf("Init with nil compareValues expected error: %v, got: %v", ErrNilCompare, err)
+			return
+		}
 	})
 }
 
 func TestSkipListDebug(t *testing.T) {
 	t.Run("DebugPrint", func(t *testing.T) {
-		s := SkipList{}
-		s.Init(1, 0.5, 0)
+		s := SkipList[int, string]{}
+		s.Init(1, 0.5, nil, strings.Compare)
 
-		s.AddOrModify(5, map[string]bool{"p": true}, nil)
-		s.AddOrModify(6, map[string]bool{"p": true}, nil)
-		s.AddOrModify(7, map[string]bool{"p": true}, nil)
+		s.Insert(5, "p")
+		s.Insert(6, "p")
+		s.Insert(7, "p")
 
 		out := s.DebugPrint()
 		if len(out) > 1 {
@@ -48,134 +74,190 @@ func TestSkipListDebug(t *testing.T) {
 
 func TestSkipListRead(t *testing.T) {
 	t.Run("Search", func(t *testing.T) {
-		s := SkipList{}
-		s.Init(5, 0.5, 0)
-		s.AddOrModify(7, map[string]bool{"1": true}, nil)
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+		s.Insert(7, "1")
 
-		result := s.SearchOrModify(7, nil)
-		if !result["1"] {
+		result := s.Search(7)
+		if len(result) != 1 || result[0].Value != "1" {
 			t.Errorf("Search function is not working properly.")
 			return
 		}
 
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Searching for key less than min key did not panic")
-					return
-				}
-			}()
-			s.SearchOrModify(-1, nil)
-		}()
+		s.Insert(-7, "1")
+		result = s.Search(-7)
+		if len(result) != 1 || result[0].Value != "1" {
+			t.Errorf("Negative key not found after insertion")
+			return
+		}
+	})
+
+	t.Run("SearchOrderedByValue", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+		s.Insert(5, "c")
+		s.Insert(5, "a")
+		s.Insert(6, "0")
+		s.Insert(4, "z")
+		s.Insert(5, "b")
+
+		result := nodeValues(s.Search(5))
+		if len(result) != 3 || result[0] != "a" || result[1] != "b" || result[2] != "c" {
+			t.Errorf("Search returned values out of order: %v", result)
+			return
+		}
 	})
 
-	t.Run("ModificationInSearch", func(t *testing.T) {
-		s := SkipList{}
-		s.Init(5, 0.5, 0)
-		s.AddOrModify(5, map[string]bool{"1": true}, nil)
+	t.Run("SearchOrderedByCompareValues", func(t *testing.T) {
+		s := SkipList[float64, int]{}
+		s.Init(5, 0.5, nil, func(a, b int) int { return cmp.Compare(b, a) })
+		s.Insert(0.5, 1)
+		s.Insert(0.5, 3)
+		s.Insert(1.5, 9)
+		s.Insert(0.5, 2)
+
+		result := s.Search(0.5)
+		if len(result) != 3 || result[0].Value != 3 || result[1].Value != 2 || result[2].Value != 1 {
+			t.Errorf("Search did not order values by compareValues")
+			return
+		}
 
-		result := s.SearchOrModify(5, func(existingValue map[string]bool) map[string]bool {
-			existingValue["Hello"] = true
-			return existingValue
-		})
+		if count := s.CountRange(KeyRange[float64]{Min: KeyBound[float64]{Key: 0.5, Exclusive: true}, Max: KeyBound[float64]{Unbounded: true}}); count != 1 {
+			t.Errorf("Count of range expected: %d, got: %d", 1, count)
+			return
+		}
 
-		if !result["1"] {
-			t.Errorf("Expected Added key %s to be there", "1")
+		nan := KeyBound[float64]{Key: math.NaN()}
+		if len(s.SearchRange(KeyRange[float64]{Min: nan, Max: KeyBound[float64]{Unbounded: true}})) != 0 || s.CountRange(KeyRange[float64]{Min: KeyBound[float64]{Unbounded: true}, Max: nan}) != 0 {
+			t.Errorf("Range with NaN bound should be empty")
 			return
 		}
 
-		if !result["Hello"] {
-			t.Errorf("Expected Added key %s to be there", "Hello")
+		if s.Insert(math.NaN(), 4) || s.Len() != 4 {
+			t.Errorf("Insertion of NaN key should return false")
 			return
 		}
 	})
 
 	t.Run("GetRangeSubset", func(t *testing.T) {
-		s := SkipList{}
-		s.Init(5, 0.5, 0)
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
 
 		for i := 0; i < 100; i++ {
-			s.AddOrModify(i, map[string]bool{strconv.Itoa(i): true}, nil)
+			s.Insert(i, strconv.Itoa(i))
 		}
 
-		s.DeleteOrModify(90, nil)
+		s.Delete(90, "90")
 
-		result := s.SearchRange(90, 95)
+		result := s.SearchRange(halfOpen(90, 95))
 		if len(result) != 4 {
 			t.Errorf("Search range returned incorrect number of result. Expected: %d, Got: %d", 5, len(result))
 		}
 
-		if !result[0]["91"] {
+		if result[0].Value != "91" {
 			t.Errorf("Wrong element returned from range call")
 			return
 		}
 
-		if !result[1]["92"] {
+		if result[1].Value != "92" {
 			t.Errorf("Wrong element returned from range call")
 			return
 		}
 
-		if !result[2]["93"] {
+		if result[2].Value != "93" {
 			t.Errorf("Wrong element returned from range call")
 			return
 		}
 
-		if !result[3]["94"] {
+		if result[3].Value != "94" {
 			t.Errorf("Wrong element returned from range call")
 			return
 		}
 
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Searching for invalid range (keyMin less than min key) did not panic")
-					return
-				}
-			}()
-			s.SearchRange(-1, 5)
-		}()
-
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Searching for invalid range (keyMin = keyMax) did not panic")
-					return
-				}
-			}()
-			s.SearchRange(5, 5)
-		}()
-
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Searching for invalid range (keyMin > keyMax) did not panic")
-					return
-				}
-			}()
-			s.SearchRange(6, 5)
-		}()
+		if len(s.SearchRange(halfOpen(-1, 5))) != 5 {
+			t.Errorf("Search range with keyMin less than min key returned incorrect number of result")
+			return
+		}
+
+		if len(s.SearchRange(halfOpen(5, 5))) != 0 || len(s.SearchRange(halfOpen(6, 5))) != 0 {
+			t.Errorf("Searching for empty or inverted range returned elements")
+			return
+		}
+
+		result = s.SearchRange(KeyRange[int]{Min: KeyBound[int]{Key: 95, Exclusive: true}, Max: KeyBound[int]{Key: 97}})
+		if len(result) != 2 || result[0].Value != "96" || result[1].Value != "97" {
+			t.Errorf("Wrong element returned from range call with exclusive min and inclusive max")
+			return
+		}
+
+		result = s.SearchRange(KeyRange[int]{Min: KeyBound[int]{Key: 98}, Max: KeyBound[int]{Unbounded: true}})
+		if len(result) != 2 || result[0].Value != "98" || result[1].Value != "99" {
+			t.Errorf("Wrong element returned from range call with unbounded max")
+			return
+		}
+	})
+
+	t.Run("GetRangeReverse", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		if len(s.SearchRangeReverse(halfOpen(0, 10))) != 0 {
+			t.Errorf("Search range reverse on empty skiplist returned elements")
+			return
+		}
+
+		for i := 0; i < 100; i++ {
+			s.Insert(i, strconv.Itoa(i))
+		}
+
+		s.Insert(93, "x93")
+		s.Delete(92, "92")
+		s.Delete(99, "99")
+
+		result := nodeValues(s.SearchRangeReverse(halfOpen(90, 95)))
+		if len(result) != 5 {
+			t.Errorf("Search range reverse returned incorrect number of result. Expected: %d, Got: %d", 5, len(result))
+			return
+		}
+
+		if result[0] != "94" || result[1] != "x93" || result[2] != "93" || result[3] != "91" || result[4] != "90" {
+			t.Errorf("Wrong element returned from range reverse call: %v", result)
+			return
+		}
+
+		result = nodeValues(s.SearchRangeReverse(halfOpen(97, 105)))
+		if len(result) != 2 || result[0] != "98" || result[1] != "97" {
+			t.Errorf("Wrong element returned from range reverse call at the tail")
+			return
+		}
+
+		result = nodeValues(s.SearchRangeReverse(KeyRange[int]{Min: KeyBound[int]{Unbounded: true}, Max: KeyBound[int]{Key: 1}}))
+		if len(result) != 2 || result[0] != "1" || result[1] != "0" {
+			t.Errorf("Wrong element returned from range reverse call at the head")
+			return
+		}
 	})
 
 	t.Run("GetRangeTail", func(t *testing.T) {
-		s := SkipList{}
-		s.Init(5, 0.5, 0)
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
 
 		for i := 0; i < 100; i++ {
-			s.AddOrModify(i, map[string]bool{strconv.Itoa(i): true}, nil)
+			s.Insert(i, strconv.Itoa(i))
 		}
 
-		result := s.SearchRange(98, 105)
+		result := s.SearchRange(halfOpen(98, 105))
 		if len(result) != 2 {
 			t.Errorf("Search range returned incorrect number of result. Expected: %d, Got: %d", 2, len(result))
 			return
 		}
 
-		if !result[0]["98"] {
+		if result[0].Value != "98" {
 			t.Errorf("Wrong element returned from range call")
 			return
 		}
 
-		if !result[1]["99"] {
+		if result[1].Value != "99" {
 			t.Errorf("Wrong element returned from range call")
 			return
 		}
@@ -183,121 +265,513 @@ func TestSkipListRead(t *testing.T) {
 	})
 }
 
+func TestSkipListLimit(t *testing.T) {
+	t.Run("SearchRangeLimit", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		for i := 0; i < 100; i++ {
+			s.Insert(i, strconv.Itoa(i))
+		}
+
+		result := nodeValues(s.SearchRangeLimit(halfOpen(10, 20), 5, 3))
+		if len(result) != 3 || result[0] != "15" || result[2] != "17" {
+			t.Errorf("Wrong element returned from limited range call: %v", result)
+			return
+		}
+
+		result = nodeValues(s.SearchRangeLimit(halfOpen(10, 20), 8, 5))
+		if len(result) != 2 || result[0] != "18" || result[1] != "19" {
+			t.Errorf("Wrong element returned from limited range call at the end: %v", result)
+			return
+		}
+
+		if len(s.SearchRangeLimit(halfOpen(10, 20), 10, 5)) != 0 || len(s.SearchRangeLimit(halfOpen(10, 20), -1, 5)) != 0 {
+			t.Errorf("Limited range call with offset outside range returned elements")
+			return
+		}
+
+		if len(s.SearchRangeLimit(halfOpen(10, 20), math.MaxInt, 5)) != 0 || len(s.SearchRangeLimit(halfOpen(10, 20), math.MaxInt-10, -1)) != 0 {
+			t.Errorf("Limited range call with huge offset returned elements")
+			return
+		}
+	})
+
+	t.Run("SearchRangeAfter", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		for i := 0; i < 100; i++ {
+			s.Insert(i/2, strconv.Itoa(i))
+		}
+
+		result := nodeValues(s.SearchRangeAfter(halfOpen(10, 20), 12, "24", 3))
+		if len(result) != 3 || result[0] != "25" || result[1] != "26" || result[2] != "27" {
+			t.Errorf("Wrong element returned from range after call: %v", result)
+			return
+		}
+
+		result = nodeValues(s.SearchRangeAfter(halfOpen(10, 20), 0, "0", 2))
+		if len(result) != 2 || result[0] != "20" || result[1] != "21" {
+			t.Errorf("Range after call did not start at range start: %v", result)
+			return
+		}
+
+		if len(s.SearchRangeAfter(halfOpen(10, 20), 19, "39", -1)) != 0 {
+			t.Errorf("Range after call past the range returned elements")
+			return
+		}
+	})
+}
+
+func TestSkipListPop(t *testing.T) {
+	t.Run("PopFirst", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		for i := 0; i < 100; i++ {
+			s.Insert(i, strconv.Itoa(i))
+		}
+
+		result := nodeValues(s.PopFirst(3))
+		if len(result) != 3 || result[0] != "0" || result[2] != "2" {
+			t.Errorf("Wrong element popped: %v", result)
+			return
+		}
+
+		if s.Rank(50, "50") != 47 || len(s.SearchByIndex(0, 0)) != 1 || s.SearchByIndex(0, 0)[0].Value != "3" {
+			t.Errorf("Skiplist is inconsistent after pop")
+			return
+		}
+
+		if len(s.PopFirst(200)) != 97 || len(s.SearchRange(halfOpen(0, 100))) != 0 || len(s.SearchRangeReverse(halfOpen(0, 100))) != 0 {
+			t.Errorf("Popping all nodes did not empty the skiplist")
+			return
+		}
+	})
+
+	t.Run("PopLast", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		for i := 0; i < 100; i++ {
+			s.Insert(i, strconv.Itoa(i))
+		}
+
+		result := nodeValues(s.PopLast(3))
+		if len(result) != 3 || result[0] != "99" || result[2] != "97" {
+			t.Errorf("Wrong element popped: %v", result)
+			return
+		}
+
+		result = nodeValues(s.SearchRangeReverse(halfOpen(95, 100)))
+		if len(result) != 2 || result[0] != "96" {
+			t.Errorf("Skiplist is inconsistent after pop: %v", result)
+			return
+		}
+
+		if s.Rank(96, "96") != 96 || s.Len() != 97 || s.nodeAt(96).Value != "96" || s.tail.Value != "96" {
+			t.Errorf("Skiplist is inconsistent after pop")
+			return
+		}
+
+		if len(s.PopLast(0)) != 0 || len(s.PopLast(-1)) != 0 {
+			t.Errorf("Popping non positive count popped nodes")
+			return
+		}
+
+		if len(s.PopLast(200)) != 97 || len(s.SearchRange(halfOpen(0, 100))) != 0 {
+			t.Errorf("Popping all nodes did not empty the skiplist")
+			return
+		}
+	})
+}
+
+func TestSkipListLex(t *testing.T) {
+	t.Run("SearchLexRange", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		for _, value := range []string{"d", "b", "a", "e", "c"} {
+			s.Insert(0, value)
+		}
+
+		result := nodeValues(s.SearchLexRange(LexBound[string]{Value: "b"}, LexBound[string]{Value: "d", Exclusive: true}))
+		if len(result) != 2 || result[0] != "b" || result[1] != "c" {
+			t.Errorf("Wrong element returned from lex range call: %v", result)
+			return
+		}
+
+		result = nodeValues(s.SearchLexRange(LexBound[string]{Value: "b", Exclusive: true}, LexBound[string]{Unbounded: true}))
+		if len(result) != 3 || result[0] != "c" || result[2] != "e" {
+			t.Errorf("Wrong element returned from lex range call: %v", result)
+			return
+		}
+
+		if len(s.SearchLexRange(LexBound[string]{Value: "d"}, LexBound[string]{Value: "b"})) != 0 {
+			t.Errorf("Inverted lex range returned elements")
+			return
+		}
+	})
+
+	t.Run("LexCount", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		for i := 0; i < 100; i++ {
+			s.Insert(0, strconv.Itoa(1000+i))
+		}
+
+		if count := s.LexCount(LexBound[string]{Value: "1010"}, LexBound[string]{Value: "1020"}); count != 11 {
+			t.Errorf("Lex count expected: %d, got: %d", 11, count)
+			return
+		}
+
+		if count := s.LexCount(LexBound[string]{Unbounded: true}, LexBound[string]{Value: "1020", Exclusive: true}); count != 20 {
+			t.Errorf("Lex count expected: %d, got: %d", 20, count)
+			return
+		}
+
+		if count := s.LexCount(LexBound[string]{Value: "1020"}, LexBound[string]{Value: "1010"}); count != 0 {
+			t.Errorf("Lex count of inverted range expected: %d, got: %d", 0, count)
+			return
+		}
+	})
+}
+
+func TestSkipListSpan(t *testing.T) {
+	t.Run("Rank", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		for i := 0; i < 100; i++ {
+			s.Insert(i, strconv.Itoa(i))
+			s.Insert(i, "x"+strconv.Itoa(i))
+		}
+
+		for i := 0; i < 100; i++ {
+			if s.Rank(i, "") != 2*i {
+				t.Errorf("Rank of %d expected: %d, got: %d", i, 2*i, s.Rank(i, ""))
+				return
+			}
+
+			if s.Rank(i, "x"+strconv.Itoa(i)) != 2*i+1 {
+				t.Errorf("Rank of %d expected: %d, got: %d", i, 2*i+1, s.Rank(i, "x"+strconv.Itoa(i)))
+				return
+			}
+		}
+
+		s.Delete(10, "10")
+		s.Delete(10, "x10")
+		s.Delete(20, "20")
+		s.Insert(30, "y30")
+
+		expected := 0
+		for i := 0; i < 100; i++ {
+			if s.Rank(i, "") != expected {
+				t.Errorf("Rank of %d expected: %d, got: %d", i, expected, s.Rank(i, ""))
+				return
+			}
+			expected += len(s.Search(i))
+		}
+	})
+
+	t.Run("CountRange", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		for i := 0; i < 100; i++ {
+			s.Insert(i/4, strconv.Itoa(i))
+		}
+
+		if count := s.CountRange(halfOpen(5, 10)); count != 20 {
+			t.Errorf("Count of range expected: %d, got: %d", 20, count)
+			return
+		}
+
+		if count := s.CountRange(KeyRange[int]{Min: KeyBound[int]{Key: 20}, Max: KeyBound[int]{Unbounded: true}}); count != 20 {
+			t.Errorf("Count of unbounded range expected: %d, got: %d", 20, count)
+			return
+		}
+
+		if count := s.CountRange(halfOpen(10, 5)); count != 0 {
+			t.Errorf("Count of inverted range expected: %d, got: %d", 0, count)
+			return
+		}
+	})
+
+	t.Run("SearchByIndex", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		for i := 0; i < 100; i++ {
+			s.Insert(i, strconv.Itoa(i))
+			s.Insert(i, "x"+strconv.Itoa(i))
+		}
+
+		result := nodeValues(s.SearchByIndex(21, 24))
+		if len(result) != 4 || result[0] != "x10" || result[1] != "11" || result[2] != "x11" || result[3] != "12" {
+			t.Errorf("Wrong element returned from search by index call: %v", result)
+			return
+		}
+
+		result = nodeValues(s.SearchByIndex(199, 300))
+		if len(result) != 1 || result[0] != "x99" {
+			t.Errorf("Wrong element returned from search by index call at the end")
+			return
+		}
+
+		if len(s.SearchByIndex(200, 300)) != 0 {
+			t.Errorf("Search by index past the end returned nodes")
+			return
+		}
+
+		if len(s.SearchByIndex(6, 5)) != 0 || len(s.SearchByIndex(-5, -1)) != 0 {
+			t.Errorf("Search by inverted or negative index range returned nodes")
+			return
+		}
+
+		result = nodeValues(s.SearchByIndex(-5, 1))
+		if len(result) != 2 || result[0] != "0" || result[1] != "x0" {
+			t.Errorf("Search by index with negative start should start at the beginning: %v", result)
+			return
+		}
+	})
+}
+
 func TestSkipListWrite(t *testing.T) {
 	t.Run("Initialization", func(t *testing.T) {
 
 	})
 
 	t.Run("Addition", func(t *testing.T) {
-		s := SkipList{}
-		s.Init(5, 0.5, 0)
-		s.AddOrModify(5, map[string]bool{"1": true}, nil)
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		if !s.Insert(5, "1") {
+			t.Errorf("Insertion of new pair should return true")
+			return
+		}
 
-		result := s.SearchOrModify(5, nil)
-		if !result["1"] {
+		result := s.Search(5)
+		if len(result) != 1 || result[0].Value != "1" {
 			t.Errorf("Expected Added key to be there")
 			return
 		}
 
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Addition of key less than minKey did not panic")
-					return
-				}
-			}()
-			s.AddOrModify(-1, map[string]bool{"-1": true}, nil)
-		}()
+		if !s.Insert(-1, "-1") || nodeValues(s.SearchByIndex(0, 1))[0] != "-1" {
+			t.Errorf("Negative key should be ordered before positive ones")
+			return
+		}
 	})
 
-	t.Run("ModificationInAddition", func(t *testing.T) {
-		s := SkipList{}
-		s.Init(5, 0.5, 0)
-		s.AddOrModify(5, map[string]bool{"1": true}, nil)
-		s.AddOrModify(5, nil, func(existingVal map[string]bool) map[string]bool {
-			existingVal["Hello"] = true
-			return existingVal
-		})
+	t.Run("DuplicateAddition", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+		s.Insert(5, "1")
 
-		result := s.SearchOrModify(5, nil)
-		if !result["1"] {
-			t.Errorf("Expected Added key %s to be there", "1")
+		if s.Insert(5, "1") {
+			t.Errorf("Insertion of existing pair should return false")
 			return
 		}
 
-		if !result["Hello"] {
-			t.Errorf("Expected Added key %s to be there", "Hello")
+		if !s.Insert(5, "Hello") {
+			t.Errorf("Insertion of new value for existing key should return true")
+			return
+		}
+
+		result := nodeValues(s.Search(5))
+		if len(result) != 2 || result[0] != "1" || result[1] != "Hello" {
+			t.Errorf("Expected values of key to be [1 Hello], got: %v", result)
 			return
 		}
 	})
 
 	t.Run("Deletion", func(t *testing.T) {
-		s := SkipList{}
-		s.Init(5, 0.5, 0)
-		s.AddOrModify(6, map[string]bool{"1": true}, nil)
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+		s.Insert(6, "1")
 
-		result := s.SearchOrModify(6, nil)
-		if !result["1"] {
+		result := s.Search(6)
+		if len(result) != 1 {
 			t.Errorf("Expected Added key to be there")
 			return
 		}
 
-		if !s.DeleteOrModify(6, nil) {
+		if !s.Delete(6, "1") {
 			t.Errorf("Deletion function did not find the key")
 			return
 		}
 
-		if len(s.SearchOrModify(6, nil)) != 0 {
+		if len(s.Search(6)) != 0 {
 			t.Errorf("Even after calling Delete, key is still there")
 			return
 		}
 
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Deletion of key less than minKey did not panic")
-					return
-				}
-			}()
+		if s.Delete(6, "1") {
+			t.Errorf("Deletion of non existant pair should return false")
+			return
+		}
+
+		s.Insert(-1, "1")
+		if !s.Delete(-1, "1") || s.Len() != 0 {
+			t.Errorf("Deletion of negative key did not find the key")
+			return
+		}
+	})
+
+	t.Run("RangeDeletion", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		for i := 0; i < 100; i++ {
+			s.Insert(i, strconv.Itoa(i))
+		}
+
+		result := nodeValues(s.DeleteRange(halfOpen(10, 20)))
+		if len(result) != 10 || result[0] != "10" || result[9] != "19" {
+			t.Errorf("Wrong nodes deleted by range deletion: %v", result)
+			return
+		}
 
-			s.DeleteOrModify(-1, nil)
-		}()
+		result = nodeValues(s.DeleteByIndex(80, 200))
+		if len(result) != 10 || result[0] != "90" || result[9] != "99" {
+			t.Errorf("Wrong nodes deleted by index deletion: %v", result)
+			return
+		}
 
+		result = nodeValues(s.DeleteByIndex(0, 0))
+		if len(result) != 1 || result[0] != "0" {
+			t.Errorf("Wrong nodes deleted by index deletion: %v", result)
+			return
+		}
+
+		if len(s.DeleteRange(halfOpen(10, 20))) != 0 || len(s.DeleteByIndex(79, 80)) != 0 {
+			t.Errorf("Deletion of empty range deleted nodes")
+			return
+		}
+
+		if len(s.DeleteByIndex(5, 4)) != 0 || len(s.DeleteByIndex(-3, -1)) != 0 {
+			t.Errorf("Deletion of inverted or negative index range deleted nodes")
+			return
+		}
+
+		expected := 0
+		for i := 0; i < 100; i++ {
+			if s.Rank(i, "") != expected {
+				t.Errorf("Rank of %d expected: %d, got: %d", i, expected, s.Rank(i, ""))
+				return
+			}
+			expected += len(s.Search(i))
+		}
+
+		if expected != 79 {
+			t.Errorf("Nodes left after range deletion expected: %d, got: %d", 79, expected)
+			return
+		}
 	})
 
-	t.Run("ModificationInDeletion", func(t *testing.T) {
-		s := SkipList{}
-		s.Init(5, 0.5, 0)
-		s.AddOrModify(6, map[string]bool{"1": true, "2": true}, nil)
+	t.Run("LexRangeDeletion", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
 
-		result := s.SearchOrModify(6, nil)
-		if !result["1"] {
-			t.Errorf("Expected Added key to be there")
+		for _, value := range []string{"d", "b", "a", "e", "c"} {
+			s.Insert(0, value)
+		}
+
+		result := nodeValues(s.DeleteLexRange(LexBound[string]{Value: "a", Exclusive: true}, LexBound[string]{Value: "d"}))
+		if len(result) != 3 || result[0] != "b" || result[2] != "d" {
+			t.Errorf("Wrong nodes deleted by lex range deletion: %v", result)
 			return
 		}
 
-		if s.DeleteOrModify(6, func(existingValue map[string]bool) (bool, map[string]bool) {
-			delete(existingValue, "2")
-			return false, existingValue
-		}) {
-			t.Errorf("Deletion function deleted key, where it should have only modified it.")
+		result = nodeValues(s.Search(0))
+		if len(result) != 2 || result[0] != "a" || result[1] != "e" {
+			t.Errorf("Wrong nodes left after lex range deletion: %v", result)
 			return
 		}
+	})
 
-		if len(s.SearchOrModify(6, nil)) == 0 {
-			t.Errorf("Even after calling Delete, key is still there")
+	t.Run("Counters", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+
+		if s.Len() != 0 || s.DistinctKeys() != 0 || s.Levels() != 0 {
+			t.Errorf("Counters of empty skiplist should be zero")
+			return
+		}
+
+		for i := 0; i < 100; i++ {
+			s.Insert(i/4, strconv.Itoa(i))
+		}
+		s.Insert(0, "0")
+
+		check := func(length, distinctKeys int) bool {
+			if s.Len() != length || s.DistinctKeys() != distinctKeys {
+				t.Errorf("Counters expected: %d nodes, %d keys, got: %d nodes, %d keys", length, distinctKeys, s.Len(), s.DistinctKeys())
+				return false
+			}
+			return true
+		}
+
+		if !check(100, 25) {
+			return
+		}
+
+		s.Delete(0, "1")
+		s.Delete(0, "0")
+		s.Delete(0, "404")
+		if !check(98, 25) {
+			return
+		}
+
+		s.Delete(0, "2")
+		s.Delete(0, "3")
+		if !check(96, 24) {
+			return
+		}
+
+		s.DeleteRange(KeyRange[int]{Min: KeyBound[int]{Key: 5}, Max: KeyBound[int]{Key: 6}})
+		s.DeleteByIndex(0, 1)
+		if !check(86, 22) {
+			return
+		}
+
+		s.PopFirst(3)
+		s.PopLast(4)
+		if !check(79, 20) {
+			return
+		}
+
+		s.PopFirst(100)
+		if !check(0, 0) || s.Levels() != 0 {
+			return
+		}
+	})
+
+	t.Run("DeletionOfOneValue", func(t *testing.T) {
+		s := SkipList[int, string]{}
+		s.Init(5, 0.5, nil, strings.Compare)
+		s.Insert(6, "1")
+		s.Insert(6, "2")
+
+		if !s.Delete(6, "2") {
+			t.Errorf("Deletion function did not find the pair")
+			return
+		}
+
+		result := nodeValues(s.Search(6))
+		if len(result) != 1 || result[0] != "1" {
+			t.Errorf("Deletion of one value affected other values of key: %v", result)
 			return
 		}
 
-		if !s.DeleteOrModify(6, func(existingValue map[string]bool) (bool, map[string]bool) {
-			delete(existingValue, "1")
-			return true, existingValue
-		}) {
-			t.Errorf("Deletion function should have deleted key.")
+		if !s.Delete(6, "1") {
+			t.Errorf("Deletion function did not find the pair")
 			return
 		}
 
-		if len(s.SearchOrModify(6, nil)) != 0 {
+		if len(s.Search(6)) != 0 {
 			t.Errorf("Search function stil found a key even after it should have been deleted")
 			return
 		}
@@ -0,0 +1,51 @@
+package sset
+
+import "iter"
+
+// All returns iterator over all members along with their rank, in ascending
+// order of rank. Members sharing same rank are ordered lexicographically.
+// Iterator holds read lock of sorted set from the start till the end of
+// iteration. It sees consistent view of sorted set without copying it, but
+// writers are blocked meanwhile. No method of the same sorted set may be
+// called from the loop body, not even read only ones like Exists or Len,
+// since they deadlock once a writer is waiting for the lock.
+// Time complexity: O(log n) to start, O(1) per member
+func (s *Set[M, S]) All() iter.Seq2[M, S] {
+	return s.Range(allScores[S]())
+}
+
+// Range returns iterator over members with rank in between Min and Max of
+// scoreRange, along with their rank, in ascending order of rank.
+// Range with NaN bound yields nothing, instead of returning ErrInvalidRange
+// like GetRange does.
+// Iterator holds read lock while iterating, just like All.
+// Time complexity: O(log n) to start, O(1) per member
+func (s *Set[M, S]) Range(scoreRange Interval[S]) iter.Seq2[M, S] {
+	return func(yield func(M, S) bool) {
+		s.rlock()
+		defer s.runlock()
+
+		for node := range s.skiplist.Ascend(scoreRange) {
+			if !yield(node.Value, node.Key) {
+				return
+			}
+		}
+	}
+}
+
+// Backward returns iterator over all members along with their rank, in
+// descending order of rank.
+// Iterator holds read lock while iterating, just like All.
+// Time complexity: O(log n) to start, O(1) per member
+func (s *Set[M, S]) Backward() iter.Seq2[M, S] {
+	return func(yield func(M, S) bool) {
+		s.rlock()
+		defer s.runlock()
+
+		for node := range s.skiplist.Descend(allScores[S]()) {
+			if !yield(node.Value, node.Key) {
+				return
+			}
+		}
+	}
+}
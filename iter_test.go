@@ -0,0 +1,72 @@
+package sset
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestSortedSetIterators(t *testing.T) {
+	s := SortedSet{}
+	s.Init()
+
+	s.Add("c", 2)
+	s.Add("a", 1)
+	s.Add("b", 1)
+	s.Add("d", 3)
+
+	collect := func(seq func(func(string, int) bool)) string {
+		var out []string
+		for member, rank := range seq {
+			out = append(out, fmt.Sprintf("%s:%d", member, rank))
+		}
+		return strings.Join(out, ",")
+	}
+
+	t.Run("SortedSetAll", func(t *testing.T) {
+		if result := collect(s.All()); result != "a:1,b:1,c:2,d:3" {
+			t.Errorf("Wrong members yielded by All: %s", result)
+			return
+		}
+	})
+
+	t.Run("SortedSetRange", func(t *testing.T) {
+		if result := collect(s.Range(ScoreRange{Min: ScoreExclusive(1), Max: ScoreUnbounded[int]()})); result != "c:2,d:3" {
+			t.Errorf("Wrong members yielded by Range: %s", result)
+			return
+		}
+
+		if result := collect(s.Range(halfOpen(3, 1))); result != "" {
+			t.Errorf("Inverted range yielded members: %s", result)
+			return
+		}
+	})
+
+	t.Run("SortedSetBackward", func(t *testing.T) {
+		if result := collect(s.Backward()); result != "d:3,c:2,b:1,a:1" {
+			t.Errorf("Wrong members yielded by Backward: %s", result)
+			return
+		}
+	})
+
+	t.Run("SortedSetIteratorBreak", func(t *testing.T) {
+		count := 0
+		for range s.All() {
+			count++
+			if count == 2 {
+				break
+			}
+		}
+
+		if count != 2 {
+			t.Errorf("Iteration did not stop on break")
+			return
+		}
+
+		// Read lock must have been released on break
+		if result, _ := s.Add("e", 5); result != Added {
+			t.Errorf("Member could not be added after iteration")
+			return
+		}
+	})
+}
@@ -0,0 +1,32 @@
+package sset
+
+// GetLexRange returns members in between min and max, in lexicographical
+// order. Just like redis ZRANGEBYLEX, it is meant for sorted set where all
+// members share same rank, otherwise result is unspecified.
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetLexRange(min, max MemberBound[M]) []M {
+	s.rlock()
+	defer s.runlock()
+
+	return nodeMembers(s.skiplist.SearchLexRange(min, max))
+}
+
+// LexCount counts members in between min and max, with same guarantees as
+// GetLexRange.
+// Time complexity: O(log n)
+func (s *Set[M, S]) LexCount(min, max MemberBound[M]) int {
+	s.rlock()
+	defer s.runlock()
+
+	return s.skiplist.LexCount(min, max)
+}
+
+// RemoveRangeByLex removes members in between min and max, with same
+// guarantees as GetLexRange. It returns number of members removed.
+// Time complexity: O(log(n) + r) where r is number of element being removed
+func (s *Set[M, S]) RemoveRangeByLex(min, max MemberBound[M]) int {
+	s.lock()
+	defer s.unlock()
+
+	return len(s.forget(s.skiplist.DeleteLexRange(min, max)))
+}
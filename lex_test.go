@@ -0,0 +1,101 @@
+package sset
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestSortedSetLex(t *testing.T) {
+	members := []string{"g", "a", "c", "f", "b", "e", "d"}
+
+	t.Run("SortedSetGetLexRange", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		if len(s.GetLexRange(LexUnbounded[string](), LexUnbounded[string]())) != 0 {
+			t.Errorf("Result length should be zero for empty sorted set")
+			return
+		}
+
+		for _, member := range members {
+			s.Add(member, 0)
+		}
+
+		result := s.GetLexRange(LexUnbounded[string](), LexInclusive("c"))
+		if strings.Join(result, ",") != "a,b,c" {
+			t.Errorf("Wrong members returned from lex range: %v", result)
+			return
+		}
+
+		result = s.GetLexRange(LexInclusive("aa"), LexExclusive("c"))
+		if strings.Join(result, ",") != "b" {
+			t.Errorf("Wrong members returned from lex range: %v", result)
+			return
+		}
+
+		result = s.GetLexRange(LexExclusive("e"), LexUnbounded[string]())
+		if strings.Join(result, ",") != "f,g" {
+			t.Errorf("Wrong members returned from lex range: %v", result)
+			return
+		}
+
+		if len(s.GetLexRange(LexExclusive("c"), LexExclusive("c"))) != 0 {
+			t.Errorf("Result length should be zero for empty lex range")
+			return
+		}
+
+		if len(s.GetLexRange(LexInclusive("e"), LexInclusive("b"))) != 0 {
+			t.Errorf("Result length should be zero for inverted lex range")
+			return
+		}
+	})
+
+	t.Run("SortedSetLexCount", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		for _, member := range members {
+			s.Add(member, 0)
+		}
+
+		if count := s.LexCount(LexUnbounded[string](), LexUnbounded[string]()); count != 7 {
+			t.Errorf("Lex count expected: %d, got: %d", 7, count)
+			return
+		}
+
+		if count := s.LexCount(LexInclusive("b"), LexExclusive("f")); count != 4 {
+			t.Errorf("Lex count expected: %d, got: %d", 4, count)
+			return
+		}
+
+		if count := s.LexCount(LexInclusive("e"), LexInclusive("b")); count != 0 {
+			t.Errorf("Lex count of inverted range expected: %d, got: %d", 0, count)
+			return
+		}
+	})
+
+	t.Run("SortedSetRemoveRangeByLex", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		for _, member := range members {
+			s.Add(member, 0)
+		}
+
+		if removed := s.RemoveRangeByLex(LexExclusive("a"), LexInclusive("c")); removed != 2 {
+			t.Errorf("Removed members expected: %d, got: %d", 2, removed)
+			return
+		}
+
+		if s.Exists("b") || s.Exists("c") {
+			t.Errorf("Removed members are still present")
+			return
+		}
+
+		result := s.GetLexRange(LexUnbounded[string](), LexUnbounded[string]())
+		if strings.Join(result, ",") != "a,d,e,f,g" {
+			t.Errorf("Wrong members left after lex removal: %v", result)
+			return
+		}
+	})
+}
@@ -0,0 +1,116 @@
+package sset
+
+// Must* methods are same as the ones without Must prefix, but panic instead
+// of returning an error. They are meant for callers who validate arguments
+// themselves, and treat invalid ones as programming errors.
+// New, Init, BlockingPopMin and BlockingPopMax have no Must* form, as their
+// errors come from configuration or context cancellation rather than from
+// invalid arguments.
+
+// must panics if err is not nil, otherwise returns value
+func must[T any](value T, err error) T {
+	if err != nil {
+		panic(err)
+	}
+	return value
+}
+
+// must2 panics if err is not nil, otherwise returns both values
+func must2[T, U any](first T, second U, err error) (T, U) {
+	if err != nil {
+		panic(err)
+	}
+	return first, second
+}
+
+// MustAdd is same as Add, but panics on error
+func (s *Set[M, S]) MustAdd(member M, rank S) AddResult {
+	return must(s.Add(member, rank))
+}
+
+// MustAddWithOptions is same as AddWithOptions, but panics on error
+func (s *Set[M, S]) MustAddWithOptions(member M, rank S, opts AddOptions) int {
+	return must(s.AddWithOptions(member, rank, opts))
+}
+
+// MustIncrementScore is same as IncrementScore, but panics on error
+func (s *Set[M, S]) MustIncrementScore(member M, delta S) S {
+	return must(s.IncrementScore(member, delta))
+}
+
+// MustRemoveRangeByScore is same as RemoveRangeByScore, but panics on error
+func (s *Set[M, S]) MustRemoveRangeByScore(scoreRange Interval[S]) int {
+	return must(s.RemoveRangeByScore(scoreRange))
+}
+
+// MustGet is same as Get, but panics on error
+func (s *Set[M, S]) MustGet(rank S) []M {
+	return must(s.Get(rank))
+}
+
+// MustGetRange is same as GetRange, but panics on error
+func (s *Set[M, S]) MustGetRange(scoreRange Interval[S]) []M {
+	return must(s.GetRange(scoreRange))
+}
+
+// MustGetRangeReverse is same as GetRangeReverse, but panics on error
+func (s *Set[M, S]) MustGetRangeReverse(scoreRange Interval[S]) []M {
+	return must(s.GetRangeReverse(scoreRange))
+}
+
+// MustCountRange is same as CountRange, but panics on error
+func (s *Set[M, S]) MustCountRange(scoreRange Interval[S]) int {
+	return must(s.CountRange(scoreRange))
+}
+
+// MustGetRangeLimit is same as GetRangeLimit, but panics on error
+func (s *Set[M, S]) MustGetRangeLimit(scoreRange Interval[S], offset, count int) []M {
+	return must(s.GetRangeLimit(scoreRange, offset, count))
+}
+
+// MustGetRangePage is same as GetRangePage, but panics on error
+func (s *Set[M, S]) MustGetRangePage(scoreRange Interval[S], pageToken string, count int) ([]M, string) {
+	return must2(s.GetRangePage(scoreRange, pageToken, count))
+}
+
+// MustGetRangeWithScores is same as GetRangeWithScores, but panics on error
+func (s *Set[M, S]) MustGetRangeWithScores(scoreRange Interval[S]) []Element[M, S] {
+	return must(s.GetRangeWithScores(scoreRange))
+}
+
+// MustGetRangeReverseWithScores is same as GetRangeReverseWithScores, but
+// panics on error
+func (s *Set[M, S]) MustGetRangeReverseWithScores(scoreRange Interval[S]) []Element[M, S] {
+	return must(s.GetRangeReverseWithScores(scoreRange))
+}
+
+// MustGetRangeLimitWithScores is same as GetRangeLimitWithScores, but
+// panics on error
+func (s *Set[M, S]) MustGetRangeLimitWithScores(scoreRange Interval[S], offset, count int) []Element[M, S] {
+	return must(s.GetRangeLimitWithScores(scoreRange, offset, count))
+}
+
+// MustPopMin is same as PopMin, but panics on error
+func (s *Set[M, S]) MustPopMin(count int) []Element[M, S] {
+	return must(s.PopMin(count))
+}
+
+// MustPopMax is same as PopMax, but panics on error
+func (s *Set[M, S]) MustPopMax(count int) []Element[M, S] {
+	return must(s.PopMax(count))
+}
+
+// MustScan is same as Scan, but panics on error
+func (s *Set[M, S]) MustScan(cursor string, count int, matchPattern string) ([]Element[M, S], string) {
+	return must2(s.Scan(cursor, count, matchPattern))
+}
+
+// MustUnion is same as Union, but panics on error
+func MustUnion[M comparable, S Number](sets []*Set[M, S], weights []S, aggregate Aggregate) *Set[M, S] {
+	return must(Union(sets, weights, aggregate))
+}
+
+// MustIntersect is same as Intersect, but panics on error
+func MustIntersect[M comparable, S Number](sets []*Set[M, S], weights []S, aggregate Aggregate) *Set[M, S] {
+	return must(Intersect(sets, weights, aggregate))
+}
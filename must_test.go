@@ -0,0 +1,70 @@
+package sset
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestSortedSetMust(t *testing.T) {
+	t.Run("MustReturnsResult", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		if s.MustAdd("Hello", 5) != Added || s.MustAddWithOptions("World", 6, AddOptions{NX: true}) != 1 {
+			t.Errorf("Must methods did not return result of wrapped method")
+			return
+		}
+
+		if s.MustIncrementScore("Hello", 2) != 7 || s.MustCountRange(halfOpen(0, 10)) != 2 {
+			t.Errorf("Must methods did not return result of wrapped method")
+			return
+		}
+
+		page, token := s.MustGetRangePage(halfOpen(0, 10), "", 1)
+		entries, cursor := s.MustScan("", 10, "W*")
+		if len(page) != 1 || page[0] != "World" || token == "" || len(entries) != 1 || cursor != "" {
+			t.Errorf("Must methods did not return results of wrapped method")
+			return
+		}
+	})
+
+	t.Run("MustPanicsWithError", func(t *testing.T) {
+		s := Set[string, float64]{}
+		s.Init()
+
+		nan := Interval[float64]{Min: ScoreInclusive(math.NaN()), Max: ScoreInclusive(1.0)}
+		calls := map[string]func(){
+			"MustAdd":            func() { s.MustAdd("Hello", math.NaN()) },
+			"MustAddWithOptions": func() { s.MustAddWithOptions("Hello", 1, AddOptions{NX: true, XX: true}) },
+			"MustGet":            func() { s.MustGet(math.NaN()) },
+			"MustGetRange":       func() { s.MustGetRange(nan) },
+			"MustPopMin":         func() { s.MustPopMin(-1) },
+			"MustGetRangePage":   func() { s.MustGetRangePage(allScores[float64](), "%%%", 1) },
+			"MustScan":           func() { s.MustScan("", 0, "") },
+			"MustUnion":          func() { MustUnion([]*Set[string, float64]{&s}, []float64{1, 2}, AggregateSum) },
+		}
+		expected := map[string]error{
+			"MustAdd":            ErrInvalidScore,
+			"MustAddWithOptions": ErrInvalidOptions,
+			"MustGet":            ErrInvalidScore,
+			"MustGetRange":       ErrInvalidRange,
+			"MustPopMin":         ErrInvalidCount,
+			"MustGetRangePage":   ErrInvalidPageToken,
+			"MustScan":           ErrInvalidCount,
+			"MustUnion":          ErrInvalidOptions,
+		}
+
+		for name, call := range calls {
+			func() {
+				defer func() {
+					err, _ := recover().(error)
+					if !errors.Is(err, expected[name]) {
+						t.Errorf("%s expected to panic with: %v, got: %v", name, expected[name], err)
+					}
+				}()
+				call()
+			}()
+		}
+	})
+}
@@ -0,0 +1,144 @@
+package sset
+
+import (
+	"cmp"
+	"fmt"
+	"math/rand"
+	"reflect"
+
+	"github.com/parthdesai/sset/internals"
+)
+
+// Option configures sorted set created by New or initiated by Init
+type Option func(*options)
+
+// options holds configuration of underlying skiplist
+// compareMembers is func(a, b M) int of WithMemberCompare, as options are not
+// specific to member type.
+type options struct {
+	maxLevels            int
+	levelJumpProbability float32
+	levels               LevelGenerator
+	compareMembers       any
+}
+
+// defaultOptions returns configuration used when no options are given
+func defaultOptions() options {
+	return options{
+		maxLevels:            32,  // log n distribution, so 2^32
+		levelJumpProbability: 0.5, // 1/2 probability of level jump
+	}
+}
+
+// WithMaxLevels sets maximum number of skiplist levels, which should be
+// around log of expected number of members. It must be at least 1.
+func WithMaxLevels(maxLevels int) Option {
+	return func(o *options) {
+		o.maxLevels = maxLevels
+	}
+}
+
+// WithLevelJumpProbability sets probability by which member present at a
+// skiplist level is also present at level above it. It must be greater
+// than 0 and less than 1.
+func WithLevelJumpProbability(probability float32) Option {
+	return func(o *options) {
+		o.levelJumpProbability = probability
+	}
+}
+
+// WithRandSource sets source of randomness used for deciding skiplist levels
+// of members. Sorted set uses source under its write lock only, so it must
+// not be shared with anything else. It replaces level generator set by
+// WithLevelGenerator.
+func WithRandSource(source rand.Source) Option {
+	return func(o *options) {
+		o.levels = internals.NewRandLevelGenerator(source)
+	}
+}
+
+// WithLevelGenerator sets generator deciding skiplist levels of members,
+// instead of xorshift generator with random seed. Sorted set uses generator
+// under its write lock only, so it must not be shared with anything else.
+// It replaces source set by WithRandSource.
+func WithLevelGenerator(levels LevelGenerator) Option {
+	return func(o *options) {
+		o.levels = levels
+	}
+}
+
+// WithMemberCompare sets function ordering members sharing same rank, instead
+// of natural ordering of members. compare must return 0 only for equal
+// members, and its member type must match member type of sorted set.
+// Sorted sets created by Union, Intersect, Diff and SymmetricDifference use
+// natural ordering.
+func WithMemberCompare[M comparable](compare func(a, b M) int) Option {
+	return func(o *options) {
+		o.compareMembers = compare
+	}
+}
+
+// LevelGenerator decides skiplist level of each member added to sorted set
+type LevelGenerator = internals.LevelGenerator
+
+// PCGLevels returns level generator drawing levels from PCG generator
+// seeded with seed1 and seed2, so that same seeds produce same skiplist.
+func PCGLevels(seed1, seed2 uint64) LevelGenerator {
+	return internals.NewPCGLevelGenerator(seed1, seed2)
+}
+
+// XorshiftLevels returns fast level generator drawing levels from xorshift
+// generator seeded with seed.
+func XorshiftLevels(seed uint64) LevelGenerator {
+	return internals.NewXorshiftLevelGenerator(seed)
+}
+
+// SequenceLevels returns level generator repeating given levels, for tests
+// which need exact shape of skiplist.
+func SequenceLevels(levels ...int) LevelGenerator {
+	return internals.NewSequenceLevelGenerator(levels...)
+}
+
+// newOptions applies opts on top of default configuration, and validates
+// the result.
+func newOptions(opts []Option) (options, error) {
+	o := defaultOptions()
+	for _, opt := range opts {
+		opt(&o)
+	}
+
+	if o.maxLevels < 1 {
+		return o, fmt.Errorf("%w: maximum number of levels must be greater than or equal to 1", ErrInvalidOptions)
+	}
+
+	if !(o.levelJumpProbability > 0 && o.levelJumpProbability < 1) {
+		return o, fmt.Errorf("%w: level jump probability must be in between 0 and 1", ErrInvalidOptions)
+	}
+
+	return o, nil
+}
+
+// memberCompare returns function ordering members sharing same rank, as
+// configured by o. It returns ErrInvalidOptions if function given by
+// WithMemberCompare is nil, or is for another member type.
+func memberCompare[M comparable](o options) (func(a, b M) int, error) {
+	if o.compareMembers == nil {
+		return compareMembers[M](), nil
+	}
+
+	compare, ok := o.compareMembers.(func(a, b M) int)
+	if !ok || compare == nil {
+		return nil, fmt.Errorf("%w: member compare function must be non nil func(a, b %v) int", ErrInvalidOptions, reflect.TypeFor[M]())
+	}
+	return compare, nil
+}
+
+// New creates sorted set configured by opts. It returns ErrInvalidOptions if
+// any option is out of range.
+func New[M comparable, S cmp.Ordered](opts ...Option) (*Set[M, S], error) {
+	s := &Set[M, S]{}
+	if err := s.Init(opts...); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
@@ -0,0 +1,145 @@
+package sset
+
+import (
+	"errors"
+	"math/rand"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+)
+
+func TestSortedSetOptions(t *testing.T) {
+	t.Run("NewWithOptions", func(t *testing.T) {
+		s, err := New[string, int](WithMaxLevels(4), WithLevelJumpProbability(0.25))
+		if err != nil {
+			t.Errorf("New with valid options returned error: %v", err)
+			return
+		}
+
+		for i := 0; i < 1000; i++ {
+			s.Add(strconv.Itoa(i), i)
+		}
+
+		if stats := s.Stats(); stats.Members != 1000 || stats.Levels > 4 {
+			t.Errorf("Wrong stats of sorted set with 4 levels: %+v", stats)
+			return
+		}
+	})
+
+	t.Run("NewWithInvalidOptions", func(t *testing.T) {
+		for _, opt := range []Option{WithMaxLevels(0), WithLevelJumpProbability(0), WithLevelJumpProbability(1)} {
+			if s, err := New[string, int](opt); !errors.Is(err, ErrInvalidOptions) || s != nil {
+				t.Errorf("New with invalid option expected error: %v, got: %v", ErrInvalidOptions, err)
+				return
+			}
+		}
+	})
+
+	t.Run("NewWithMemberCompare", func(t *testing.T) {
+		s, err := New[string, int](WithMemberCompare(func(a, b string) int { return strings.Compare(b, a) }))
+		if err != nil {
+			t.Errorf("New with member compare returned error: %v", err)
+			return
+		}
+
+		for _, member := range []string{"b", "c", "a"} {
+			s.Add(member, 0)
+		}
+		s.Add("z", -1)
+
+		if result := s.GetByIndexRange(0, -1); strings.Join(result, ",") != "z,c,b,a" {
+			t.Errorf("Members sharing rank are not ordered by member compare: %v", result)
+			return
+		}
+
+		for _, opt := range []Option{WithMemberCompare(func(a, b int) int { return a - b }), WithMemberCompare[string](nil)} {
+			if _, err := New[string, int](opt); !errors.Is(err, ErrInvalidOptions) {
+				t.Errorf("New with invalid member compare expected error: %v, got: %v", ErrInvalidOptions, err)
+				return
+			}
+		}
+	})
+
+	t.Run("NewWithRandSource", func(t *testing.T) {
+		first, _ := New[string, int](WithRandSource(rand.NewSource(42)))
+		second, _ := New[string, int](WithRandSource(rand.NewSource(42)))
+
+		for i := 0; i < 100; i++ {
+			first.Add(strconv.Itoa(i), i)
+			second.Add(strconv.Itoa(i), i)
+		}
+
+		if !slices.Equal(first.skiplist.DebugPrint(), second.skiplist.DebugPrint()) {
+			t.Errorf("Sorted sets with same seed have different shape")
+			return
+		}
+	})
+
+	t.Run("NewWithLevelGenerator", func(t *testing.T) {
+		s, _ := New[string, int](WithMaxLevels(8), WithLevelGenerator(SequenceLevels(0, 3)))
+		s.Add("a", 1)
+		s.Add("b", 2)
+
+		if levels := s.Stats().Levels; levels != 4 {
+			t.Errorf("Levels decided by sequence expected: %d, got: %d", 4, levels)
+			return
+		}
+
+		first, _ := New[string, int](WithLevelGenerator(PCGLevels(1, 2)))
+		second, _ := New[string, int](WithLevelGenerator(PCGLevels(1, 2)))
+		for i := 0; i < 100; i++ {
+			first.Add(strconv.Itoa(i), i)
+			second.Add(strconv.Itoa(i), i)
+		}
+
+		if !slices.Equal(first.skiplist.DebugPrint(), second.skiplist.DebugPrint()) {
+			t.Errorf("Sorted sets with same PCG seeds have different shape")
+			return
+		}
+	})
+
+	t.Run("ZeroValue", func(t *testing.T) {
+		s := SortedSet{}
+
+		if s.Len() != 0 || s.Exists("Hello") {
+			t.Errorf("Zero value sorted set should be empty")
+			return
+		}
+
+		wg := sync.WaitGroup{}
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func(i int) {
+				defer wg.Done()
+				s.Add(strconv.Itoa(i), i)
+			}(i)
+		}
+		wg.Wait()
+
+		if s.Len() != 10 || s.MustGet(5)[0] != "5" {
+			t.Errorf("Zero value sorted set lost members added concurrently")
+			return
+		}
+	})
+
+	t.Run("InitTwice", func(t *testing.T) {
+		s := SortedSet{}
+		if err := s.Init(); err != nil {
+			t.Errorf("First Init returned error: %v", err)
+			return
+		}
+
+		s.Add("Hello", 5)
+		if err := s.Init(); !errors.Is(err, ErrAlreadyInitialized) || !s.Exists("Hello") {
+			t.Errorf("Second Init expected error: %v, got: %v", ErrAlreadyInitialized, err)
+			return
+		}
+
+		if err := (&SortedSet{}).Init(WithMaxLevels(-1)); !errors.Is(err, ErrInvalidOptions) {
+			t.Errorf("Init with invalid option expected error: %v, got: %v", ErrInvalidOptions, err)
+			return
+		}
+	})
+}
@@ -0,0 +1,139 @@
+package sset
+
+import (
+	"bytes"
+	"cmp"
+	"encoding/base64"
+	"encoding/gob"
+	"errors"
+	"fmt"
+
+	"github.com/parthdesai/sset/internals"
+)
+
+// ErrInvalidPageToken is returned when page token passed to GetRangePage
+// was not produced by GetRangePage.
+var ErrInvalidPageToken = errors.New("sset: invalid page token")
+
+// ErrUnencodableMember is returned by GetRangePage and Scan when member does
+// not survive encoding with encoding/gob in page token or cursor unchanged.
+var ErrUnencodableMember = errors.New("sset: member can not be encoded")
+
+// GetRangeLimit returns members with rank in between Min and Max of
+// scoreRange, skipping first offset members and returning at most count
+// members, just like redis ZRANGEBYSCORE with LIMIT.
+// Negative count returns all members after offset. Range with NaN bound is
+// rejected with ErrInvalidRange.
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetRangeLimit(scoreRange Interval[S], offset, count int) ([]M, error) {
+	if err := checkRange(scoreRange); err != nil {
+		return nil, err
+	}
+
+	s.rlock()
+	defer s.runlock()
+
+	return nodeMembers(s.skiplist.SearchRangeLimit(scoreRange, offset, count)), nil
+}
+
+// GetRangePage returns at most count members with rank in between Min and Max
+// of scoreRange, continuing after the page identified by pageToken. Empty
+// pageToken starts from the beginning of the range. Returned token continues
+// with the next page, and is empty when there are no more members.
+// Members added or removed in between calls do not affect position of the
+// next page. It returns ErrInvalidCount if count is not positive, and
+// ErrInvalidRange if scoreRange has NaN bound.
+// Token holds member encoded with encoding/gob, so ErrUnencodableMember is
+// returned when next page exists and decoding its member does not give back
+// equal member, e.g. for pointers, structs with unexported fields, or
+// interfaces holding types not registered with gob.
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetRangePage(scoreRange Interval[S], pageToken string, count int) ([]M, string, error) {
+	if count <= 0 {
+		return nil, "", fmt.Errorf("%w: count must be greater than zero", ErrInvalidCount)
+	}
+
+	if err := checkRange(scoreRange); err != nil {
+		return nil, "", err
+	}
+
+	s.rlock()
+	defer s.runlock()
+
+	nodes, nextPageToken, err := s.searchPage(scoreRange, pageToken, count, ErrInvalidPageToken)
+	if err != nil {
+		return nil, "", err
+	}
+
+	return nodeMembers(nodes), nextPageToken, nil
+}
+
+// searchPage finds at most count nodes with rank in between Min and Max of
+// scoreRange, ordered after position encoded in token, along with token of
+// the next page. It returns errInvalid if token is invalid.
+// Caller must hold read lock.
+func (s *Set[M, S]) searchPage(scoreRange Interval[S], token string, count int, errInvalid error) ([]*internals.Node[S, M], string, error) {
+	var nodes []*internals.Node[S, M]
+	if token == "" {
+		nodes = s.skiplist.SearchRangeLimit(scoreRange, 0, count+1)
+	} else {
+		pos, ok := decodePosition[M, S](token)
+		if !ok {
+			return nil, "", errInvalid
+		}
+		nodes = s.skiplist.SearchRangeAfter(scoreRange, pos.Rank, pos.Member, count+1)
+	}
+
+	// One extra member was fetched to find out whether next page exists
+	nextToken := ""
+	if len(nodes) > count {
+		nodes = nodes[:count]
+
+		var err error
+		nextToken, err = encodePosition(position[M, S]{Rank: nodes[count-1].Key, Member: nodes[count-1].Value})
+		if err != nil {
+			return nil, "", err
+		}
+	}
+
+	return nodes, nextToken, nil
+}
+
+// position identifies place of member in sorted set, it is encoded with
+// encoding/gob, see ErrUnencodableMember.
+type position[M comparable, S cmp.Ordered] struct {
+	Rank   S
+	Member M
+}
+
+// encodePosition encodes position of member in sorted set as opaque token.
+// Token is decoded back right away, as gob silently changes some members,
+// and resuming from changed member would skip or repeat members.
+func encodePosition[M comparable, S cmp.Ordered](pos position[M, S]) (string, error) {
+	buf := bytes.Buffer{}
+	if err := gob.NewEncoder(&buf).Encode(pos); err != nil {
+		return "", fmt.Errorf("%w: %w", ErrUnencodableMember, err)
+	}
+
+	token := base64.RawURLEncoding.EncodeToString(buf.Bytes())
+	if decoded, ok := decodePosition[M, S](token); !ok || decoded != pos {
+		return "", fmt.Errorf("%w: %v changes when decoded", ErrUnencodableMember, pos.Member)
+	}
+	return token, nil
+}
+
+// decodePosition decodes position encoded by encodePosition
+func decodePosition[M comparable, S cmp.Ordered](token string) (position[M, S], bool) {
+	var pos position[M, S]
+
+	decoded, err := base64.RawURLEncoding.DecodeString(token)
+	if err != nil {
+		return pos, false
+	}
+
+	if err := gob.NewDecoder(bytes.NewReader(decoded)).Decode(&pos); err != nil {
+		return pos, false
+	}
+
+	return pos, true
+}
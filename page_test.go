@@ -0,0 +1,161 @@
+package sset
+
+import (
+	"errors"
+	"math"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestSortedSetPagination(t *testing.T) {
+	t.Run("SortedSetGetRangeLimit", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		for i := 0; i < 20; i++ {
+			s.Add(strconv.Itoa(i), i)
+		}
+
+		result := s.MustGetRangeLimit(halfOpen(5, 15), 2, 3)
+		if strings.Join(result, ",") != "7,8,9" {
+			t.Errorf("Wrong members returned from limited range: %v", result)
+			return
+		}
+
+		result = s.MustGetRangeLimit(halfOpen(5, 15), 8, 5)
+		if strings.Join(result, ",") != "13,14" {
+			t.Errorf("Wrong members returned from limited range at the end: %v", result)
+			return
+		}
+
+		result = s.MustGetRangeLimit(halfOpen(5, 15), 7, -1)
+		if strings.Join(result, ",") != "12,13,14" {
+			t.Errorf("Wrong members returned from range with negative count: %v", result)
+			return
+		}
+
+		if len(s.MustGetRangeLimit(halfOpen(5, 15), 10, 5)) != 0 || len(s.MustGetRangeLimit(halfOpen(5, 15), -1, 5)) != 0 {
+			t.Errorf("Result length should be zero for offset outside of range")
+			return
+		}
+
+		scoreRange := ScoreRange{Min: ScoreInclusive(5), Max: ScoreUnbounded[int]()}
+		if result := s.MustGetRangeLimit(scoreRange, math.MaxInt, 3); len(result) != 0 {
+			t.Errorf("Huge offset returned members: %v", result)
+			return
+		}
+
+		if result := s.MustGetRangeLimit(halfOpen(5, 8), math.MaxInt-3, -1); len(result) != 0 {
+			t.Errorf("Huge offset returned members: %v", result)
+			return
+		}
+	})
+
+	t.Run("SortedSetGetRangePage", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		for i := 0; i < 10; i++ {
+			s.Add("a"+strconv.Itoa(i), i/2)
+		}
+
+		scoreRange := ScoreRange{Min: ScoreInclusive(1), Max: ScoreUnbounded[int]()}
+
+		page, token, err := s.GetRangePage(scoreRange, "", 3)
+		if err != nil || strings.Join(page, ",") != "a2,a3,a4" || token == "" {
+			t.Errorf("Wrong first page returned: %v", page)
+			return
+		}
+
+		// Modifications in between pages should not shift next page
+		s.Remove("a4")
+		s.Add("a0", 9)
+
+		page, token, err = s.GetRangePage(scoreRange, token, 3)
+		if err != nil || strings.Join(page, ",") != "a5,a6,a7" || token == "" {
+			t.Errorf("Wrong second page returned: %v", page)
+			return
+		}
+
+		page, token, err = s.GetRangePage(scoreRange, token, 3)
+		if err != nil || strings.Join(page, ",") != "a8,a9,a0" || token != "" {
+			t.Errorf("Wrong last page returned: %v, token: %q", page, token)
+			return
+		}
+
+		if _, _, err = s.GetRangePage(scoreRange, "%%%", 3); err != ErrInvalidPageToken {
+			t.Errorf("Invalid page token expected error: %v, got: %v", ErrInvalidPageToken, err)
+			return
+		}
+	})
+
+	t.Run("GetRangePageUnencodableMembers", func(t *testing.T) {
+		type key struct {
+			region string
+			id     int64
+		}
+		type unregistered struct{ ID int }
+
+		s := Set[key, int]{}
+		for i := int64(0); i < 5; i++ {
+			s.Add(key{"eu", i}, 0)
+		}
+
+		if page, _, err := s.GetRangePage(allScores[int](), "", 5); err != nil || len(page) != 5 {
+			t.Errorf("Single page of unencodable members returned %v, error: %v", page, err)
+			return
+		}
+
+		if _, _, err := s.GetRangePage(allScores[int](), "", 2); !errors.Is(err, ErrUnencodableMember) {
+			t.Errorf("Page token of struct without exported fields expected error: %v, got: %v", ErrUnencodableMember, err)
+			return
+		}
+
+		if _, _, err := s.Scan("", 2, ""); !errors.Is(err, ErrUnencodableMember) {
+			t.Errorf("Scan cursor of struct without exported fields expected error: %v, got: %v", ErrUnencodableMember, err)
+			return
+		}
+
+		type partlyExported struct {
+			A int
+			b int
+		}
+
+		partSet := Set[partlyExported, int]{}
+		pointerSet := Set[*int, int]{}
+		for i := 0; i < 6; i++ {
+			partSet.Add(partlyExported{i, i + 1}, i)
+			pointerSet.Add(new(int), i)
+		}
+
+		if _, _, err := partSet.GetRangePage(allScores[int](), "", 2); !errors.Is(err, ErrUnencodableMember) {
+			t.Errorf("Page token of struct with unexported fields expected error: %v, got: %v", ErrUnencodableMember, err)
+			return
+		}
+
+		if _, _, err := partSet.Scan("", 2, ""); !errors.Is(err, ErrUnencodableMember) {
+			t.Errorf("Scan cursor of struct with unexported fields expected error: %v, got: %v", ErrUnencodableMember, err)
+			return
+		}
+
+		if _, _, err := pointerSet.GetRangePage(allScores[int](), "", 2); !errors.Is(err, ErrUnencodableMember) {
+			t.Errorf("Page token of pointer member expected error: %v, got: %v", ErrUnencodableMember, err)
+			return
+		}
+
+		if _, _, err := pointerSet.Scan("", 2, ""); !errors.Is(err, ErrUnencodableMember) {
+			t.Errorf("Scan cursor of pointer member expected error: %v, got: %v", ErrUnencodableMember, err)
+			return
+		}
+
+		anySet := Set[any, int]{}
+		anySet.Add(unregistered{1}, 0)
+		anySet.Add(unregistered{2}, 0)
+
+		if _, _, err := anySet.GetRangePage(allScores[int](), "", 1); !errors.Is(err, ErrUnencodableMember) {
+			t.Errorf("Page token of unregistered interface member expected error: %v, got: %v", ErrUnencodableMember, err)
+			return
+		}
+	})
+}
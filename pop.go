@@ -0,0 +1,133 @@
+package sset
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/parthdesai/sset/internals"
+)
+
+// PopMin removes and returns at most count members with lowest rank, in
+// ascending order of rank. Negative count is rejected with ErrInvalidCount.
+// Time complexity: O(r) where r is number of element being removed
+func (s *Set[M, S]) PopMin(count int) ([]Element[M, S], error) {
+	if count < 0 {
+		return nil, fmt.Errorf("%w: count must be greater than or equal to zero", ErrInvalidCount)
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	return nodeEntries(s.forget(s.skiplist.PopFirst(count))), nil
+}
+
+// PopMax removes and returns at most count members with highest rank, in
+// descending order of rank. Negative count is rejected with ErrInvalidCount.
+// Time complexity: O(log(n) + r) where r is number of element being removed
+func (s *Set[M, S]) PopMax(count int) ([]Element[M, S], error) {
+	if count < 0 {
+		return nil, fmt.Errorf("%w: count must be greater than or equal to zero", ErrInvalidCount)
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	return nodeEntries(s.forget(s.skiplist.PopLast(count))), nil
+}
+
+// BlockingPopMin is same as PopMin, but if sorted set is empty, it waits
+// until a member is added or ctx is done, just like redis BZPOPMIN.
+// Waiting goroutines are served in the order they started waiting.
+// It returns ctx.Err() if ctx is done before any member could be popped, and
+// ErrInvalidCount if count is not positive.
+func (s *Set[M, S]) BlockingPopMin(ctx context.Context, count int) ([]Element[M, S], error) {
+	return s.blockingPop(ctx, count, s.skiplist.PopFirst)
+}
+
+// BlockingPopMax is same as PopMax, but if sorted set is empty, it waits
+// until a member is added or ctx is done, just like redis BZPOPMAX.
+// Waiting goroutines are served in the order they started waiting.
+// It returns ctx.Err() if ctx is done before any member could be popped, and
+// ErrInvalidCount if count is not positive.
+func (s *Set[M, S]) BlockingPopMax(ctx context.Context, count int) ([]Element[M, S], error) {
+	return s.blockingPop(ctx, count, s.skiplist.PopLast)
+}
+
+// blockingPop pops members using pop, waiting in queue of waiters while
+// sorted set is empty.
+func (s *Set[M, S]) blockingPop(ctx context.Context, count int, pop func(int) []*internals.Node[S, M]) ([]Element[M, S], error) {
+	if count <= 0 {
+		return nil, fmt.Errorf("%w: count must be greater than zero", ErrInvalidCount)
+	}
+
+	wake := make(chan struct{}, 1)
+	woken := false
+
+	for {
+		s.lock()
+
+		if woken {
+			s.woken--
+		}
+
+		// Newcomers must not overtake goroutines already waiting or woken
+		if len(s.dict) > 0 && (woken || (len(s.waiters) == 0 && s.woken == 0)) {
+			entries := nodeEntries(s.forget(pop(count)))
+			if len(s.dict) > 0 {
+				s.wakeWaiter()
+			}
+			s.unlock()
+			return entries, nil
+		}
+
+		// Woken goroutine lost the member to a non blocking call, so it
+		// keeps its place at the front of the queue
+		if woken {
+			s.waiters = append([]chan struct{}{wake}, s.waiters...)
+		} else {
+			s.waiters = append(s.waiters, wake)
+		}
+		woken = false
+
+		s.unlock()
+
+		select {
+		case <-wake:
+			woken = true
+		case <-ctx.Done():
+			s.lock()
+			if !s.removeWaiter(wake) {
+				// Woken concurrently, pass wake up on to the next waiter
+				s.woken--
+				s.wakeWaiter()
+			}
+			s.unlock()
+			return nil, ctx.Err()
+		}
+	}
+}
+
+// wakeWaiter wakes goroutine waiting for the longest time, if any.
+// Caller must hold write lock.
+func (s *Set[M, S]) wakeWaiter() {
+	if len(s.waiters) == 0 {
+		return
+	}
+
+	wake := s.waiters[0]
+	s.waiters = s.waiters[1:]
+	s.woken++
+	wake <- struct{}{}
+}
+
+// removeWaiter removes wake from queue of waiters, returning false if it
+// was already woken. Caller must hold write lock.
+func (s *Set[M, S]) removeWaiter(wake chan struct{}) bool {
+	for i, waiting := range s.waiters {
+		if waiting == wake {
+			s.waiters = append(s.waiters[:i], s.waiters[i+1:]...)
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,266 @@
+package sset
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"testing"
+	"time"
+)
+
+func TestSortedSetPop(t *testing.T) {
+	t.Run("SortedSetPopMin", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		if len(s.MustPopMin(1)) != 0 {
+			t.Errorf("Pop from empty sorted set returned entries")
+			return
+		}
+
+		s.Add("c", 2)
+		s.Add("a", 1)
+		s.Add("b", 1)
+		s.Add("d", 3)
+
+		result := s.MustPopMin(3)
+		if fmt.Sprint(result) != "[{a 1} {b 1} {c 2}]" {
+			t.Errorf("Wrong entries popped: %v", result)
+			return
+		}
+
+		if s.Exists("a") || s.IndexOf("d") != 0 {
+			t.Errorf("Popped members are still present")
+			return
+		}
+
+		result = s.MustPopMin(5)
+		if fmt.Sprint(result) != "[{d 3}]" {
+			t.Errorf("Wrong entries popped: %v", result)
+			return
+		}
+
+		s.Add("e", 5)
+		if fmt.Sprint(s.GetByIndexRangeWithScores(0, -1)) != "[{e 5}]" {
+			t.Errorf("Sorted set is inconsistent after popping all members")
+			return
+		}
+	})
+
+	t.Run("SortedSetPopMax", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		s.Add("c", 2)
+		s.Add("a", 1)
+		s.Add("b", 1)
+		s.Add("d", 3)
+
+		result := s.MustPopMax(3)
+		if fmt.Sprint(result) != "[{d 3} {c 2} {b 1}]" {
+			t.Errorf("Wrong entries popped: %v", result)
+			return
+		}
+
+		if s.Exists("d") || rankOf(&s, "a") != 1 {
+			t.Errorf("Popped members are still present")
+			return
+		}
+
+		if len(s.MustPopMax(0)) != 0 {
+			t.Errorf("Pop of zero members returned entries")
+			return
+		}
+
+		if _, err := s.PopMax(-1); !errors.Is(err, ErrInvalidCount) {
+			t.Errorf("Negative count expected error: %v, got: %v", ErrInvalidCount, err)
+			return
+		}
+	})
+
+	t.Run("SortedSetConcurrentPop", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		for i := 0; i < 1000; i++ {
+			s.Add(strconv.Itoa(i), i)
+		}
+
+		var mutex sync.Mutex
+		var wg sync.WaitGroup
+		popped := map[string]bool{}
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for entries := s.MustPopMin(7); len(entries) != 0; entries = s.MustPopMin(7) {
+					mutex.Lock()
+					for _, entry := range entries {
+						if popped[entry.Member] {
+							t.Errorf("Member %s popped twice", entry.Member)
+						}
+						popped[entry.Member] = true
+					}
+					mutex.Unlock()
+				}
+			}()
+		}
+		wg.Wait()
+
+		if len(popped) != 1000 {
+			t.Errorf("Popped members expected: %d, got: %d", 1000, len(popped))
+			return
+		}
+	})
+}
+
+// waitForWaiters waits until given number of goroutines are queued in s
+func waitForWaiters(s *SortedSet, count int) {
+	for {
+		s.rwMutex.RLock()
+		queued := len(s.waiters)
+		s.rwMutex.RUnlock()
+		if queued == count {
+			return
+		}
+		time.Sleep(time.Millisecond)
+	}
+}
+
+func TestSortedSetBlockingPop(t *testing.T) {
+	t.Run("SortedSetBlockingPopMin", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		s.Add("a", 1)
+		result, err := s.BlockingPopMin(context.Background(), 2)
+		if err != nil || fmt.Sprint(result) != "[{a 1}]" {
+			t.Errorf("Wrong entries popped from non empty sorted set: %v", result)
+			return
+		}
+
+		done := make(chan []Entry)
+		go func() {
+			result, _ := s.BlockingPopMin(context.Background(), 2)
+			done <- result
+		}()
+
+		waitForWaiters(&s, 1)
+		s.Add("b", 2)
+
+		if result := <-done; fmt.Sprint(result) != "[{b 2}]" {
+			t.Errorf("Wrong entries popped after waiting: %v", result)
+			return
+		}
+	})
+
+	t.Run("SortedSetBlockingPopMax", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		done := make(chan []Entry)
+		go func() {
+			result, _ := s.BlockingPopMax(context.Background(), 1)
+			done <- result
+		}()
+
+		waitForWaiters(&s, 1)
+		s.AddWithOptions("b", 2, AddOptions{})
+
+		if result := <-done; fmt.Sprint(result) != "[{b 2}]" {
+			t.Errorf("Wrong entries popped after waiting: %v", result)
+			return
+		}
+	})
+
+	t.Run("SortedSetBlockingPopCancellation", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+
+		if _, err := s.BlockingPopMin(ctx, 1); err != context.DeadlineExceeded {
+			t.Errorf("Expected error: %v, got: %v", context.DeadlineExceeded, err)
+			return
+		}
+
+		if len(s.waiters) != 0 {
+			t.Errorf("Cancelled goroutine is still queued")
+			return
+		}
+
+		s.Add("a", 1)
+		if !s.Exists("a") {
+			t.Errorf("Member added after cancellation was popped")
+			return
+		}
+	})
+
+	t.Run("SortedSetBlockingPopFairness", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		results := make([]chan []Entry, 3)
+		for i := range results {
+			results[i] = make(chan []Entry, 1)
+			go func(i int) {
+				result, _ := s.BlockingPopMin(context.Background(), 1)
+				results[i] <- result
+			}(i)
+			waitForWaiters(&s, i+1)
+		}
+
+		for i := range results {
+			s.Add(strconv.Itoa(i), i)
+			if result := <-results[i]; len(result) != 1 || result[0].Member != strconv.Itoa(i) {
+				t.Errorf("Waiter %d expected member %d, got: %v", i, i, result)
+				return
+			}
+		}
+	})
+
+	t.Run("SortedSetBlockingPopConcurrent", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		ctx, cancel := context.WithCancel(context.Background())
+		var wg sync.WaitGroup
+		popped := make(chan Entry, 1000)
+
+		for i := 0; i < 10; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					entries, err := s.BlockingPopMin(ctx, 3)
+					if err != nil {
+						return
+					}
+					for _, entry := range entries {
+						popped <- entry
+					}
+				}
+			}()
+		}
+
+		for i := 0; i < 1000; i++ {
+			s.Add(strconv.Itoa(i), i)
+		}
+
+		seen := map[string]bool{}
+		for len(seen) < 1000 {
+			entry := <-popped
+			if seen[entry.Member] {
+				t.Errorf("Member %s popped twice", entry.Member)
+			}
+			seen[entry.Member] = true
+		}
+
+		cancel()
+		wg.Wait()
+	})
+}
@@ -0,0 +1,147 @@
+package sset
+
+import (
+	"errors"
+	"fmt"
+)
+
+// ErrInvalidCursor is returned when cursor passed to Scan was not produced
+// by Scan.
+var ErrInvalidCursor = errors.New("sset: invalid scan cursor")
+
+// Scan incrementally iterates over sorted set, just like redis ZSCAN.
+// Empty cursor starts a new scan, and each call examines at most count
+// members following cursor, returning ones matching matchPattern along with
+// the cursor to continue from. Scan is complete when returned cursor is
+// empty. A call may return no members even though scan is not complete.
+// Read lock is only held during each call. Members present with same rank
+// for the entire scan are returned exactly once, members added, removed or
+// re-ranked meanwhile may or may not be returned.
+// matchPattern is glob style pattern applied to members, supporting *, ?,
+// [...] character classes and \ escapes. Empty pattern matches all members.
+// Members which are not strings are matched in their fmt.Sprint form.
+// It returns ErrInvalidCount if count is not positive. Cursor holds member
+// encoded with encoding/gob, so just like GetRangePage, ErrUnencodableMember
+// is returned when it does not decode back equal.
+// Time complexity: O(log(n) + count)
+func (s *Set[M, S]) Scan(cursor string, count int, matchPattern string) ([]Element[M, S], string, error) {
+	if count <= 0 {
+		return nil, "", fmt.Errorf("%w: count must be greater than zero", ErrInvalidCount)
+	}
+
+	s.rlock()
+	defer s.runlock()
+
+	nodes, nextCursor, err := s.searchPage(allScores[S](), cursor, count, ErrInvalidCursor)
+	if err != nil {
+		return nil, "", err
+	}
+
+	entries := []Element[M, S]{}
+	for _, node := range nodes {
+		if matchPattern == "" || matchGlob(matchPattern, memberString(node.Value)) {
+			entries = append(entries, Element[M, S]{Member: node.Value, Score: node.Key})
+		}
+	}
+
+	return entries, nextCursor, nil
+}
+
+// memberString returns member as string matched by Scan
+func memberString[M comparable](member M) string {
+	if str, ok := any(member).(string); ok {
+		return str
+	}
+	return fmt.Sprint(member)
+}
+
+// matchGlob reports whether member matches glob style pattern
+func matchGlob(pattern, member string) bool {
+	for len(pattern) > 0 {
+		switch pattern[0] {
+		case '*':
+			for len(pattern) > 1 && pattern[1] == '*' {
+				pattern = pattern[1:]
+			}
+			if len(pattern) == 1 {
+				return true
+			}
+			for i := 0; i <= len(member); i++ {
+				if matchGlob(pattern[1:], member[i:]) {
+					return true
+				}
+			}
+			return false
+
+		case '?':
+			if len(member) == 0 {
+				return false
+			}
+
+		case '[':
+			if len(member) == 0 {
+				return false
+			}
+			matched, rest, ok := matchClass(pattern[1:], member[0])
+			if ok {
+				if !matched {
+					return false
+				}
+				pattern, member = rest, member[1:]
+				continue
+			}
+			// Unterminated class, '[' is matched literally
+			if member[0] != '[' {
+				return false
+			}
+
+		case '\\':
+			if len(pattern) > 1 {
+				pattern = pattern[1:]
+			}
+			fallthrough
+
+		default:
+			if len(member) == 0 || member[0] != pattern[0] {
+				return false
+			}
+		}
+
+		pattern, member = pattern[1:], member[1:]
+	}
+
+	return len(member) == 0
+}
+
+// matchClass matches c against character class, pattern being the part
+// following '['. It returns whether c matched and pattern following the
+// closing ']', and false if class is not terminated.
+func matchClass(pattern string, c byte) (bool, string, bool) {
+	negate := len(pattern) > 0 && pattern[0] == '^'
+	if negate {
+		pattern = pattern[1:]
+	}
+
+	matched := false
+	for i := 0; i < len(pattern); {
+		switch {
+		case pattern[i] == ']':
+			return matched != negate, pattern[i+1:], true
+		case pattern[i] == '\\' && i+1 < len(pattern):
+			matched = matched || pattern[i+1] == c
+			i += 2
+		case i+2 < len(pattern) && pattern[i+1] == '-' && pattern[i+2] != ']':
+			low, high := pattern[i], pattern[i+2]
+			if low > high {
+				low, high = high, low
+			}
+			matched = matched || (low <= c && c <= high)
+			i += 3
+		default:
+			matched = matched || pattern[i] == c
+			i++
+		}
+	}
+
+	return false, "", false
+}
@@ -0,0 +1,111 @@
+package sset
+
+import (
+	"errors"
+	"strconv"
+	"testing"
+)
+
+func TestSortedSetScan(t *testing.T) {
+	t.Run("SortedSetScanAll", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		for i := 0; i < 100; i++ {
+			s.Add("m"+strconv.Itoa(i), i%7)
+		}
+
+		seen := map[string]bool{}
+		cursor := ""
+		for calls := 0; ; calls++ {
+			entries, next, err := s.Scan(cursor, 9, "")
+			if err != nil || len(entries) > 9 {
+				t.Errorf("Scan call returned error: %v, with %d entries", err, len(entries))
+				return
+			}
+
+			for _, entry := range entries {
+				if seen[entry.Member] {
+					t.Errorf("Member %s returned twice", entry.Member)
+					return
+				}
+				if rankOf(&s, entry.Member) != entry.Score {
+					t.Errorf("Wrong rank returned for member %s", entry.Member)
+					return
+				}
+				seen[entry.Member] = true
+			}
+
+			// Members added and removed during scan should not affect others
+			s.Add("new"+strconv.Itoa(calls), calls%7)
+			s.Remove("new" + strconv.Itoa(calls-1))
+
+			if next == "" {
+				break
+			}
+			cursor = next
+		}
+
+		for i := 0; i < 100; i++ {
+			if !seen["m"+strconv.Itoa(i)] {
+				t.Errorf("Member m%d was missed by scan", i)
+				return
+			}
+		}
+	})
+
+	t.Run("SortedSetScanMatch", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		for _, member := range []string{"user:1", "user:2", "user:10", "admin:1", "user:x"} {
+			s.Add(member, 0)
+		}
+
+		entries, next, err := s.Scan("", 10, "user:[0-9]*")
+		if err != nil || next != "" || len(entries) != 3 {
+			t.Errorf("Scan with pattern returned %v, cursor: %q, error: %v", entries, next, err)
+			return
+		}
+
+		if _, _, err = s.Scan("", 0, ""); !errors.Is(err, ErrInvalidCount) {
+			t.Errorf("Scan with zero count expected error: %v, got: %v", ErrInvalidCount, err)
+			return
+		}
+
+		if _, _, err = s.Scan("???", 10, ""); err != ErrInvalidCursor {
+			t.Errorf("Invalid cursor expected error: %v, got: %v", ErrInvalidCursor, err)
+			return
+		}
+	})
+
+	t.Run("MatchGlob", func(t *testing.T) {
+		cases := []struct {
+			pattern string
+			member  string
+			matched bool
+		}{
+			{"*", "", true},
+			{"h?llo", "hello", true},
+			{"h?llo", "hllo", false},
+			{"h*llo", "heeeello", true},
+			{"h*llo", "hello!", false},
+			{"h[ae]llo", "hallo", true},
+			{"h[ae]llo", "hillo", false},
+			{"h[^e]llo", "hallo", true},
+			{"h[^e]llo", "hello", false},
+			{"h[a-c]llo", "hbllo", true},
+			{"h[a-c]llo", "hdllo", false},
+			{"h\\*llo", "h*llo", true},
+			{"h\\*llo", "hello", false},
+			{"h[llo", "h[llo", true},
+			{"a**b*c", "axxbyyc", true},
+		}
+
+		for _, c := range cases {
+			if matchGlob(c.pattern, c.member) != c.matched {
+				t.Errorf("Pattern %q on %q expected: %v", c.pattern, c.member, c.matched)
+			}
+		}
+	})
+}
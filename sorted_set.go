@@ -1,157 +1,522 @@
 package sset
 
 import (
+	"cmp"
+	"errors"
+	"fmt"
 	"sync"
+	"sync/atomic"
 
 	"github.com/parthdesai/sset/internals"
 )
 
-const levelJumpProbability = 0.5 // 1/2 probability of level jump
-const maxLevels = 32             // log n distribution, so 2^32
-const minKey = 0
-
-// SortedSet struct represent sorted set abstract data structure
+// Set struct represent sorted set abstract data structure, holding members
+// of type M ordered by rank of type S.
 // Under the hood, it uses skiplist and dict for sorted set functionality and
 // Read write mutex for thread safe operation
-type SortedSet struct {
-	dict     internals.Dictionary
-	skiplist internals.SkipList
-	rwMutex  *sync.RWMutex
+// Zero value is ready to use with default options, it is initiated on first
+// use. Set must not be copied after first use.
+// GetRangePage and Scan encode members in tokens with encoding/gob, so they
+// fail with ErrUnencodableMember for members which do not decode back equal,
+// such as pointers, structs with unexported fields, and interfaces holding
+// types not registered with gob.Register.
+type Set[M comparable, S cmp.Ordered] struct {
+	dict     internals.Dictionary[M, S]
+	skiplist internals.SkipList[S, M]
+	// id orders locking of multiple sorted sets, such as in Union
+	id      uint64
+	once    sync.Once
+	rwMutex sync.RWMutex
+	// waiters queues goroutines blocked in BlockingPopMin or BlockingPopMax,
+	// which are woken one at a time as members are added
+	waiters []chan struct{}
+	woken   int
 }
 
-// Init Initiates sorted set.
-func (s *SortedSet) Init() {
-	s.dict = internals.Dictionary{}
-	s.skiplist = internals.SkipList{}
-	s.rwMutex = &sync.RWMutex{}
-	s.skiplist.Init(maxLevels, levelJumpProbability, minKey)
+// lastID is id of the most recently initiated sorted set
+var lastID atomic.Uint64
+
+// SortedSet is sorted set of string members with int ranks
+type SortedSet = Set[string, int]
+
+// Init Initiates sorted set, configured by opts.
+// Members sharing same rank are ordered by value, unless WithMemberCompare
+// is given. Strings and numbers are ordered naturally, false before true,
+// arrays and structs element by element, and pointers by their address.
+// It returns ErrInvalidOptions if any option is out of range, and
+// ErrAlreadyInitialized if sorted set was already initiated, explicitly or
+// by its first use, in which case sorted set is left untouched.
+func (s *Set[M, S]) Init(opts ...Option) error {
+	o, err := newOptions(opts)
+	if err != nil {
+		return err
+	}
+
+	compare, err := memberCompare[M](o)
+	if err != nil {
+		return err
+	}
+
+	err = ErrAlreadyInitialized
+	s.once.Do(func() {
+		err = s.init(o, compare)
+	})
+	return err
 }
 
-// Add adds a string element to sorted set, with rank indicated by
-// rank parameter.
-//Time complexity: O(log n)
-func (s *SortedSet) Add(member string, rank int) bool {
+// init initiates dict and skiplist, it must be called only once
+func (s *Set[M, S]) init(o options, compare func(a, b M) int) error {
+	s.id = lastID.Add(1)
+	s.dict = internals.Dictionary[M, S]{}
+	return s.skiplist.Init(o.maxLevels, o.levelJumpProbability, o.levels, compare)
+}
 
-	if rank < 0 {
-		panic("Rank must be greater than or equal to zero")
-	}
+// lazyInit initiates sorted set with default options, unless it was
+// already initiated
+func (s *Set[M, S]) lazyInit() {
+	s.once.Do(func() {
+		// Default options are always valid
+		s.init(defaultOptions(), compareMembers[M]())
+	})
+}
 
+// lock initiates sorted set if needed, and acquires write lock
+func (s *Set[M, S]) lock() {
+	s.lazyInit()
 	s.rwMutex.Lock()
-	defer s.rwMutex.Unlock()
+}
 
-	if _, ok := s.dict[member]; ok {
-		return false
+// unlock releases write lock
+func (s *Set[M, S]) unlock() {
+	s.rwMutex.Unlock()
+}
+
+// rlock initiates sorted set if needed, and acquires read lock
+func (s *Set[M, S]) rlock() {
+	s.lazyInit()
+	s.rwMutex.RLock()
+}
+
+// runlock releases read lock
+func (s *Set[M, S]) runlock() {
+	s.rwMutex.RUnlock()
+}
+
+// Errors returned by sorted set, possibly wrapped with more details.
+// Use errors.Is to check for them.
+var (
+	// ErrInvalidScore is returned when rank can not be stored in sorted set,
+	// such as NaN.
+	ErrInvalidScore = errors.New("sset: invalid score")
+	// ErrInvalidRange is returned when bound of range is NaN
+	ErrInvalidRange = errors.New("sset: invalid range")
+	// ErrInvalidOptions is returned when conflicting AddOptions, out of
+	// range Option, or weights and aggregate not matching sets are given
+	ErrInvalidOptions = errors.New("sset: invalid options")
+	// ErrAlreadyInitialized is returned when Init is called on sorted set
+	// which was already initiated
+	ErrAlreadyInitialized = errors.New("sset: already initialized")
+	// ErrInvalidCount is returned when count of members is out of range
+	ErrInvalidCount = errors.New("sset: invalid count")
+)
+
+// AddResult reports what Add did with the member
+type AddResult int
+
+const (
+	// Unchanged indicates member was already present with the same rank
+	Unchanged AddResult = iota
+	// Added indicates member was not present and has been added
+	Added
+	// Updated indicates member was present and has been moved to new rank
+	Updated
+)
+
+// AddOptions controls conditional addition done by AddWithOptions.
+// Flags have same meaning as the ones accepted by redis ZADD.
+type AddOptions struct {
+	NX bool // Only add new members, never update existing ones
+	XX bool // Only update existing members, never add new ones
+	GT bool // Only update existing members if new rank is greater
+	LT bool // Only update existing members if new rank is less
+	CH bool // Count updated members along with added ones
+}
+
+// Add adds an element to sorted set, with rank indicated by
+// rank parameter. If member already exists, its rank is updated atomically.
+// NaN rank is rejected with ErrInvalidScore.
+// Time complexity: O(log n)
+func (s *Set[M, S]) Add(member M, rank S) (AddResult, error) {
+
+	if isNaN(rank) {
+		return Unchanged, fmt.Errorf("%w: rank is not a number", ErrInvalidScore)
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	return s.add(member, rank, AddOptions{}), nil
+}
+
+// AddWithOptions adds or updates member subject to conditions given in opts.
+// It returns number of members added, or with CH number of members added
+// or updated, just like redis ZADD. NaN rank is rejected with
+// ErrInvalidScore, and conflicting options with ErrInvalidOptions.
+// Time complexity: O(log n)
+func (s *Set[M, S]) AddWithOptions(member M, rank S, opts AddOptions) (int, error) {
+
+	if opts.NX && opts.XX {
+		return 0, fmt.Errorf("%w: NX and XX are mutually exclusive", ErrInvalidOptions)
+	}
+
+	if (opts.GT && opts.LT) || (opts.NX && (opts.GT || opts.LT)) {
+		return 0, fmt.Errorf("%w: GT, LT and NX are mutually exclusive", ErrInvalidOptions)
+	}
+
+	if isNaN(rank) {
+		return 0, fmt.Errorf("%w: rank is not a number", ErrInvalidScore)
+	}
+
+	s.lock()
+	defer s.unlock()
+
+	switch s.add(member, rank, opts) {
+	case Added:
+		return 1, nil
+	case Updated:
+		if opts.CH {
+			return 1, nil
+		}
+	}
+	return 0, nil
+}
+
+// add adds or updates member, honoring conditions given in opts.
+// Caller must hold write lock.
+func (s *Set[M, S]) add(member M, rank S, opts AddOptions) AddResult {
+	currentRank, ok := s.dict[member]
+
+	switch {
+	case ok && currentRank == rank:
+		return Unchanged
+	case ok && opts.NX, !ok && opts.XX:
+		return Unchanged
+	case ok && opts.GT && rank < currentRank, ok && opts.LT && rank > currentRank:
+		return Unchanged
+	}
+
+	if ok {
+		s.unlink(member, currentRank)
 	}
 
 	s.dict[member] = rank
-	s.skiplist.AddOrModify(rank, map[string]bool{member: true}, func(currentVal map[string]bool) map[string]bool {
-		currentVal[member] = true
-		return currentVal
-	})
+	s.link(member, rank)
 
-	return true
+	if ok {
+		return Updated
+	}
+
+	s.wakeWaiter()
+	return Added
+}
+
+// IncrementScore increments rank of member by delta, adding member with
+// rank delta if it does not exist. It returns new rank of member, or
+// ErrInvalidScore if resulting rank would overflow or be NaN, in which case
+// sorted set is left untouched and current rank is returned.
+// For string ranks, delta is appended.
+// Time complexity: O(log n)
+func (s *Set[M, S]) IncrementScore(member M, delta S) (S, error) {
+	s.lock()
+	defer s.unlock()
+
+	var zero S
+	currentRank := s.dict[member]
+	newRank := currentRank + delta
+
+	var err error
+	switch {
+	case isNaN(newRank):
+		err = fmt.Errorf("%w: resulting rank is not a number", ErrInvalidScore)
+	// Integer overflow wraps around, moving rank against direction of delta
+	case (delta > zero && newRank < currentRank) || (delta < zero && newRank > currentRank):
+		err = fmt.Errorf("%w: resulting rank is out of range", ErrInvalidScore)
+	}
+
+	if err != nil {
+		return currentRank, err
+	}
+
+	s.add(member, newRank, AddOptions{})
+	return newRank, nil
 }
 
 // Remove Removes member from sorted set
 // Time complexity: O(log n)
-func (s *SortedSet) Remove(member string) bool {
-	s.rwMutex.Lock()
-	defer s.rwMutex.Unlock()
+func (s *Set[M, S]) Remove(member M) bool {
+	s.lock()
+	defer s.unlock()
 
 	val, ok := s.dict[member]
 	if !ok {
 		return false
 	}
 
-	deleted := false
-
 	delete(s.dict, member)
-	s.skiplist.DeleteOrModify(val, func(memberMap map[string]bool) (bool, map[string]bool) {
-		deleted = true
-		delete(memberMap, member)
-		if len(memberMap) == 0 {
-			return true, nil
-		}
-		return false, memberMap
-	})
+	s.unlink(member, val)
 
-	return deleted
+	return true
 }
 
-// Get gets member(s) with given rank
-// time complexity: O(log n)
-func (s *SortedSet) Get(rank int) []string {
-	if rank < 0 {
-		panic("Rank must be greater than or equal to zero")
+// RemoveRangeByScore removes all members with rank in between Min and Max of
+// scoreRange. It returns number of members removed, or ErrInvalidRange if
+// scoreRange has NaN bound.
+// Time complexity: O(log(n) + r) where r is number of element being removed
+func (s *Set[M, S]) RemoveRangeByScore(scoreRange Interval[S]) (int, error) {
+	if err := checkRange(scoreRange); err != nil {
+		return 0, err
 	}
 
-	s.rwMutex.RLock()
-	defer s.rwMutex.RUnlock()
+	s.lock()
+	defer s.unlock()
 
-	memberMap := s.skiplist.SearchOrModify(rank, nil)
-	members := make([]string, len(memberMap))
+	return len(s.forget(s.skiplist.DeleteRange(scoreRange))), nil
+}
+
+// RemoveRangeByIndex removes members with 0-based position in between start
+// and stop, both inclusive. Negative start and stop are counted from the end,
+// just like GetByIndexRange. It returns number of members removed.
+// Time complexity: O(log(n) + r) where r is number of element being removed
+func (s *Set[M, S]) RemoveRangeByIndex(start, stop int) int {
+	s.lock()
+	defer s.unlock()
 
-	i := 0
-	for value := range memberMap {
-		members[i] = value
-		i++
+	start, stop, ok := normalizeIndexRange(start, stop, len(s.dict))
+	if !ok {
+		return 0
 	}
 
-	return members
+	return len(s.forget(s.skiplist.DeleteByIndex(start, stop)))
 }
 
-// GetRange returns all members with rank in between rankMin and rankMax
-// rankMin is inclusive
-// Time complexity: O(log(n) + r) where r is number of element being returned
-func (s *SortedSet) GetRange(rankMin, rankMax int) []string {
-	if rankMin < 0 || rankMax < 0 {
-		panic("rankMin and rankMax must be greater than equal to zero")
+// link adds member to skiplist at given rank. Caller must hold write lock.
+func (s *Set[M, S]) link(member M, rank S) {
+	s.skiplist.Insert(rank, member)
+}
+
+// unlink removes member from skiplist. Caller must hold write lock.
+func (s *Set[M, S]) unlink(member M, rank S) {
+	s.skiplist.Delete(rank, member)
+}
+
+// forget removes dict entries of members already deleted from skiplist.
+// Caller must hold write lock.
+func (s *Set[M, S]) forget(nodes []*internals.Node[S, M]) []*internals.Node[S, M] {
+	for _, node := range nodes {
+		delete(s.dict, node.Value)
 	}
+	return nodes
+}
 
-	if rankMin >= rankMax {
-		panic("rankMin must be less than rankMax")
+// Get gets member(s) with given rank, in lexicographical order
+// NaN rank is rejected with ErrInvalidScore.
+// time complexity: O(log n + r) where r is number of element being returned
+func (s *Set[M, S]) Get(rank S) ([]M, error) {
+	if isNaN(rank) {
+		return nil, fmt.Errorf("%w: rank is not a number", ErrInvalidScore)
 	}
 
-	s.rwMutex.RLock()
-	defer s.rwMutex.RUnlock()
+	s.rlock()
+	defer s.runlock()
 
-	searchResult := s.skiplist.SearchRange(rankMin, rankMax)
+	return nodeMembers(s.skiplist.Search(rank)), nil
+}
 
-	numberOfMembers := 0
-	for _, memberMap := range searchResult {
-		numberOfMembers += len(memberMap)
+// GetRange returns all members with rank in between Min and Max of
+// scoreRange. Empty or inverted range yields no members, and range with NaN
+// bound is rejected with ErrInvalidRange.
+// Members sharing same rank are ordered lexicographically.
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetRange(scoreRange Interval[S]) ([]M, error) {
+	if err := checkRange(scoreRange); err != nil {
+		return nil, err
 	}
 
-	members := make([]string, numberOfMembers)
-	fillIndex := 0
-	for _, memberMap := range searchResult {
-		for value := range memberMap {
-			members[fillIndex] = value
-			fillIndex++
-		}
+	s.rlock()
+	defer s.runlock()
+
+	return nodeMembers(s.skiplist.SearchRange(scoreRange)), nil
+}
+
+// GetRangeReverse returns all members with rank in between Min and Max of
+// scoreRange, in descending order of rank, with same errors as GetRange.
+// Members sharing same rank are ordered in reverse lexicographical order.
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetRangeReverse(scoreRange Interval[S]) ([]M, error) {
+	if err := checkRange(scoreRange); err != nil {
+		return nil, err
 	}
 
+	s.rlock()
+	defer s.runlock()
+
+	return nodeMembers(s.skiplist.SearchRangeReverse(scoreRange)), nil
+}
+
+// CountRange counts members with rank in between Min and Max of scoreRange,
+// without collecting them, with same errors as GetRange.
+// Time complexity: O(log n)
+func (s *Set[M, S]) CountRange(scoreRange Interval[S]) (int, error) {
+	if err := checkRange(scoreRange); err != nil {
+		return 0, err
+	}
+
+	s.rlock()
+	defer s.runlock()
+
+	return s.skiplist.CountRange(scoreRange), nil
+}
+
+// nodeMembers collects members of skiplist nodes into a single slice
+func nodeMembers[M comparable, S cmp.Ordered](nodes []*internals.Node[S, M]) []M {
+	members := make([]M, len(nodes))
+	for i, node := range nodes {
+		members[i] = node.Value
+	}
 	return members
 }
 
+// IndexOf gives 0-based position of member in sorted set, ordered by rank.
+// Members sharing same rank are ordered lexicographically.
+// Returns -1 if member does not exist.
+// Time complexity: O(log n)
+func (s *Set[M, S]) IndexOf(member M) int {
+	s.rlock()
+	defer s.runlock()
+
+	rank, ok := s.dict[member]
+	if !ok {
+		return -1
+	}
+
+	return s.skiplist.Rank(rank, member)
+}
+
+// GetByIndexRange returns members with 0-based position in between start and
+// stop, both inclusive. Negative start and stop are counted from the end of
+// sorted set, -1 being the last member. Members sharing same rank are ordered
+// lexicographically.
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetByIndexRange(start, stop int) []M {
+	s.rlock()
+	defer s.runlock()
+
+	return nodeMembers(s.searchByIndex(start, stop, false))
+}
+
+// GetByIndexRangeReverse returns members with 0-based position in between
+// start and stop, both inclusive, where position 0 is the member with highest
+// rank. Negative start and stop are counted from the end, just like
+// GetByIndexRange. Members sharing same rank are ordered in reverse
+// lexicographical order.
+// Time complexity: O(log(n) + r) where r is number of element being returned
+func (s *Set[M, S]) GetByIndexRangeReverse(start, stop int) []M {
+	s.rlock()
+	defer s.runlock()
+
+	return nodeMembers(s.searchByIndex(start, stop, true))
+}
+
+// searchByIndex finds nodes with position in between start and stop, which
+// can be negative. If reverse is true, position 0 is the member with highest
+// rank. Caller must hold read lock.
+func (s *Set[M, S]) searchByIndex(start, stop int, reverse bool) []*internals.Node[S, M] {
+	length := len(s.dict)
+	start, stop, ok := normalizeIndexRange(start, stop, length)
+	if !ok {
+		return nil
+	}
+
+	if !reverse {
+		return s.skiplist.SearchByIndex(start, stop)
+	}
+
+	nodes := s.skiplist.SearchByIndex(length-1-stop, length-1-start)
+	for i, j := 0, len(nodes)-1; i < j; i, j = i+1, j-1 {
+		nodes[i], nodes[j] = nodes[j], nodes[i]
+	}
+
+	return nodes
+}
+
+// normalizeIndexRange converts negative indexes to positive ones, and clamps
+// them to sorted set of given length. It returns false if range is empty.
+func normalizeIndexRange(start, stop, length int) (int, int, bool) {
+	if start < 0 {
+		start += length
+	}
+	if stop < 0 {
+		stop += length
+	}
+	if start < 0 {
+		start = 0
+	}
+	if stop >= length {
+		stop = length - 1
+	}
+	return start, stop, start <= stop
+}
+
+// Stats holds size accounting of sorted set
+type Stats struct {
+	Members        int // Number of members
+	DistinctScores int // Number of distinct ranks among members
+	Levels         int // Number of skiplist levels currently in use
+}
+
+// Len returns number of members in sorted set
+// Time complexity: O(1)
+func (s *Set[M, S]) Len() int {
+	s.rlock()
+	defer s.runlock()
+
+	return len(s.dict)
+}
+
+// Stats returns size accounting of sorted set
+// Time complexity: O(1)
+func (s *Set[M, S]) Stats() Stats {
+	s.rlock()
+	defer s.runlock()
+
+	return Stats{
+		Members:        len(s.dict),
+		DistinctScores: s.skiplist.DistinctKeys(),
+		Levels:         s.skiplist.Levels(),
+	}
+}
+
 // Exists check for membership of member in sorted set
 // Time complexity: O(1)
-func (s *SortedSet) Exists(member string) bool {
-	s.rwMutex.RLock()
-	defer s.rwMutex.RUnlock()
+func (s *Set[M, S]) Exists(member M) bool {
+	s.rlock()
+	defer s.runlock()
 
 	_, ok := s.dict[member]
 	return ok
 }
 
-// GetRank gives rank of member
+// GetRank gives rank of member, and false if member does not exist
 // Time complexity: O(1)
-func (s *SortedSet) GetRank(member string) int {
-	s.rwMutex.RLock()
-	defer s.rwMutex.RUnlock()
+func (s *Set[M, S]) GetRank(member M) (S, bool) {
+	s.rlock()
+	defer s.runlock()
 
-	if val, ok := s.dict[member]; ok {
-		return val
-	}
-	return -1
+	rank, ok := s.dict[member]
+	return rank, ok
+}
+
+// isNaN reports whether rank is NaN, the only value not equal to itself
+func isNaN[S cmp.Ordered](rank S) bool {
+	return rank != rank
 }
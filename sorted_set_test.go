@@ -1,21 +1,37 @@
 package sset
 
 import (
+	"cmp"
+	"errors"
+	"math"
+	"strconv"
+	"strings"
 	"testing"
 )
 
+// rankOf returns rank of member, ignoring whether member exists
+func rankOf[M comparable, S cmp.Ordered](s *Set[M, S], member M) S {
+	rank, _ := s.GetRank(member)
+	return rank
+}
+
+// halfOpen returns range of ranks including rankMin and excluding rankMax
+func halfOpen(rankMin, rankMax int) ScoreRange {
+	return ScoreRange{Min: ScoreInclusive(rankMin), Max: ScoreExclusive(rankMax)}
+}
+
 func TestSortedSetWrite(t *testing.T) {
 	t.Run("SortedSetAdd", func(t *testing.T) {
 		s := SortedSet{}
 		s.Init()
 
-		result := s.Get(5)
+		result := s.MustGet(5)
 		if len(result) != 0 {
 			t.Errorf("Result length should not be greater than zero for Get call")
 			return
 		}
 
-		result = s.GetRange(5, 10)
+		result = s.MustGetRange(halfOpen(5, 10))
 		if len(result) != 0 {
 			t.Errorf("Result length should not be greater than zero for GetRange call")
 			return
@@ -25,7 +41,7 @@ func TestSortedSetWrite(t *testing.T) {
 		s.Add("World", 5)
 		s.Add("World2", 6)
 
-		result = s.Get(5)
+		result = s.MustGet(5)
 		if result[0] != "Hello" && result[1] != "Hello" {
 			t.Errorf("Hello not present in result.")
 			return
@@ -36,20 +52,205 @@ func TestSortedSetWrite(t *testing.T) {
 			return
 		}
 
-		if s.Add("World2", 7) {
-			t.Errorf("World2 should not have been added")
+		if result, _ := s.Add("World2", 6); result != Unchanged {
+			t.Errorf("World2 should have been left unchanged")
+			return
+		}
+
+		if result, _ := s.Add("World2", 7); result != Updated {
+			t.Errorf("World2 should have been updated")
+			return
+		}
+
+		if len(s.MustGet(6)) != 0 {
+			t.Errorf("World2 is still present at its old rank")
+			return
+		}
+
+		result = s.MustGet(7)
+		if len(result) != 1 || result[0] != "World2" {
+			t.Errorf("World2 not present at its new rank")
+			return
+		}
+
+		if rank, _ := s.GetRank("World2"); rank != 7 {
+			t.Errorf("Rank of World2 expected: %d, got: %d", 7, rank)
+			return
+		}
+
+		if result, _ := s.Add("World3", 7); result != Added {
+			t.Errorf("World3 should have been added")
+			return
+		}
+
+		if result, _ := s.Add("NegativeRank", -1); result != Added || s.IndexOf("NegativeRank") != 0 {
+			t.Errorf("NegativeRank should have been added before other members")
+			return
+		}
+	})
+
+	t.Run("SortedSetAddWithOptions", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		if added, _ := s.AddWithOptions("Hello", 5, AddOptions{XX: true}); added != 0 || s.Exists("Hello") {
+			t.Errorf("XX should not add new member")
+			return
+		}
+
+		if added, _ := s.AddWithOptions("Hello", 5, AddOptions{NX: true}); added != 1 {
+			t.Errorf("NX should add new member")
+			return
+		}
+
+		if added, _ := s.AddWithOptions("Hello", 6, AddOptions{NX: true}); added != 0 || rankOf(&s, "Hello") != 5 {
+			t.Errorf("NX should not update existing member")
+			return
+		}
+
+		if added, _ := s.AddWithOptions("Hello", 4, AddOptions{GT: true, CH: true}); added != 0 || rankOf(&s, "Hello") != 5 {
+			t.Errorf("GT should not update member to lower rank")
+			return
+		}
+
+		if added, _ := s.AddWithOptions("Hello", 8, AddOptions{GT: true, CH: true}); added != 1 || rankOf(&s, "Hello") != 8 {
+			t.Errorf("GT should update member to greater rank")
+			return
+		}
+
+		if added, _ := s.AddWithOptions("Hello", 9, AddOptions{LT: true, CH: true}); added != 0 || rankOf(&s, "Hello") != 8 {
+			t.Errorf("LT should not update member to greater rank")
+			return
+		}
+
+		if added, _ := s.AddWithOptions("Hello", 2, AddOptions{LT: true, XX: true}); added != 0 || rankOf(&s, "Hello") != 2 {
+			t.Errorf("Update without CH should be counted as zero")
+			return
+		}
+
+		if added, _ := s.AddWithOptions("World", 3, AddOptions{GT: true}); added != 1 {
+			t.Errorf("GT should not prevent adding new member")
+			return
+		}
+
+		result := s.MustGet(2)
+		if len(result) != 1 || result[0] != "Hello" {
+			t.Errorf("Hello not present at its new rank")
+			return
+		}
+
+		for _, opts := range []AddOptions{{NX: true, XX: true}, {GT: true, LT: true}, {NX: true, GT: true}} {
+			if _, err := s.AddWithOptions("Conflict", 1, opts); !errors.Is(err, ErrInvalidOptions) || s.Exists("Conflict") {
+				t.Errorf("Conflicting options %+v expected error: %v, got: %v", opts, ErrInvalidOptions, err)
+				return
+			}
+		}
+	})
+
+	t.Run("SortedSetIncrementScore", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		if score, err := s.IncrementScore("Hello", 5); err != nil || score != 5 {
+			t.Errorf("Increment of non existant member expected: %d, got: %d", 5, score)
+			return
+		}
+
+		if score, err := s.IncrementScore("Hello", 3); err != nil || score != 8 {
+			t.Errorf("Increment of existing member expected: %d, got: %d", 8, score)
+			return
+		}
+
+		if len(s.MustGet(5)) != 0 {
+			t.Errorf("Hello is still present at its old rank")
+			return
+		}
+
+		result := s.MustGet(8)
+		if len(result) != 1 || result[0] != "Hello" {
+			t.Errorf("Hello not present at its new rank")
+			return
+		}
+
+		if score, err := s.IncrementScore("Hello", -9); err != nil || score != -1 {
+			t.Errorf("Increment below zero expected: %d, got: %d", -1, score)
+			return
+		}
+
+		if score, err := s.IncrementScore("World", math.MinInt); err != nil || score != math.MinInt {
+			t.Errorf("Increment of non existant member expected: %d, got: %d", math.MinInt, score)
+			return
+		}
+
+		if score, err := s.IncrementScore("World", -1); !errors.Is(err, ErrInvalidScore) || score != math.MinInt {
+			t.Errorf("Increment overflowing rank should have been rejected")
+			return
+		}
+	})
+
+	t.Run("SortedSetRemoveRangeByScore", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		for i := 0; i < 20; i++ {
+			s.Add(strconv.Itoa(i), i/2)
+		}
+
+		if removed := s.MustRemoveRangeByScore(ScoreRange{Min: ScoreUnbounded[int](), Max: ScoreExclusive(3)}); removed != 6 {
+			t.Errorf("Removed members expected: %d, got: %d", 6, removed)
 			return
 		}
 
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Negative Rank addition did not panic")
-					return
-				}
-			}()
-			s.Add("NegativeRank", -1)
-		}()
+		if s.Exists("5") || !s.Exists("6") || s.IndexOf("6") != 0 {
+			t.Errorf("Sorted set is inconsistent after range removal")
+			return
+		}
+
+		if removed := s.MustRemoveRangeByScore(halfOpen(5, 5)); removed != 0 {
+			t.Errorf("Removal of empty range removed %d members", removed)
+			return
+		}
+
+		if removed := s.MustRemoveRangeByScore(ScoreRange{Min: ScoreInclusive(8), Max: ScoreUnbounded[int]()}); removed != 4 {
+			t.Errorf("Removed members expected: %d, got: %d", 4, removed)
+			return
+		}
+
+		result := s.GetByIndexRange(0, -1)
+		if strings.Join(result, ",") != "6,7,8,9,10,11,12,13,14,15" {
+			t.Errorf("Wrong members left after range removal: %v", result)
+			return
+		}
+	})
+
+	t.Run("SortedSetRemoveRangeByIndex", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		for i := 0; i < 10; i++ {
+			s.Add(strconv.Itoa(i), i)
+		}
+
+		if removed := s.RemoveRangeByIndex(2, 4); removed != 3 {
+			t.Errorf("Removed members expected: %d, got: %d", 3, removed)
+			return
+		}
+
+		if removed := s.RemoveRangeByIndex(-2, -1); removed != 2 {
+			t.Errorf("Removed members expected: %d, got: %d", 2, removed)
+			return
+		}
+
+		if removed := s.RemoveRangeByIndex(5, 10); removed != 0 {
+			t.Errorf("Removal of empty index range removed %d members", removed)
+			return
+		}
+
+		result := s.GetByIndexRange(0, -1)
+		if strings.Join(result, ",") != "0,1,5,6,7" || s.Exists("9") || s.Exists("2") {
+			t.Errorf("Wrong members left after index range removal: %v", result)
+			return
+		}
 	})
 
 	t.Run("SortedSetRemove", func(t *testing.T) {
@@ -69,7 +270,7 @@ func TestSortedSetWrite(t *testing.T) {
 			return
 		}
 
-		result := s.Get(5)
+		result := s.MustGet(5)
 		if len(result) != 1 {
 			t.Errorf("Length of result should be: 1, got: %d", len(result))
 			return
@@ -92,7 +293,7 @@ func TestSortedSetRead(t *testing.T) {
 		s.Add("Member2", 5)
 		s.Add("Member3", 6)
 
-		result := s.Get(5)
+		result := s.MustGet(5)
 
 		if len(result) != 2 {
 			t.Errorf("Expected length of result to be 2, actual is: %d", len(result))
@@ -109,15 +310,11 @@ func TestSortedSetRead(t *testing.T) {
 			return
 		}
 
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Negative Rank searching did not panic")
-					return
-				}
-			}()
-			s.Get(-1)
-		}()
+		s.Add("Member4", -5)
+		if result := s.MustGet(-5); len(result) != 1 || result[0] != "Member4" {
+			t.Errorf("Member4 not present at negative rank")
+			return
+		}
 
 	})
 
@@ -125,7 +322,7 @@ func TestSortedSetRead(t *testing.T) {
 		s := SortedSet{}
 		s.Init()
 
-		result := s.GetRange(5, 10)
+		result := s.MustGetRange(halfOpen(5, 10))
 		if len(result) != 0 {
 			t.Errorf("Result length should not be greater than zero for GetRange call")
 			return
@@ -135,7 +332,7 @@ func TestSortedSetRead(t *testing.T) {
 		s.Add("World", 5)
 		s.Add("World2", 6)
 
-		result = s.GetRange(4, 6)
+		result = s.MustGetRange(halfOpen(4, 6))
 		if len(result) != 2 {
 			t.Errorf("Result length for GetRange expected: %d, got: %d", 3, len(result))
 			return
@@ -151,35 +348,263 @@ func TestSortedSetRead(t *testing.T) {
 			return
 		}
 
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Negative Rank searching did not panic")
-					return
-				}
-			}()
-			s.GetRange(-1, 5)
-		}()
-
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Invalid Range Rank searching did not panic")
-					return
-				}
-			}()
-			s.GetRange(5, 5)
-		}()
-
-		func() {
-			defer func() {
-				if r := recover(); r == nil {
-					t.Errorf("Invalid Range Rank searching did not panic")
-					return
-				}
-			}()
-			s.GetRange(5, 1)
-		}()
+		result = s.MustGetRange(halfOpen(-1, 6))
+		if len(result) != 2 {
+			t.Errorf("Result length for GetRange with negative rankMin expected: %d, got: %d", 2, len(result))
+			return
+		}
+
+		if len(s.MustGetRange(halfOpen(5, 5))) != 0 || len(s.MustGetRange(halfOpen(5, 1))) != 0 {
+			t.Errorf("Result length should be zero for empty or inverted range")
+			return
+		}
+
+		result = s.MustGetRange(ScoreRange{Min: ScoreExclusive(5), Max: ScoreUnbounded[int]()})
+		if len(result) != 1 || result[0] != "World2" {
+			t.Errorf("Wrong members returned for range exclusive of rankMin: %v", result)
+			return
+		}
+
+		result = s.MustGetRange(ScoreRange{Min: ScoreInclusive(5), Max: ScoreInclusive(5)})
+		if strings.Join(result, ",") != "Hello,World" {
+			t.Errorf("Wrong members returned for range of exactly one rank: %v", result)
+			return
+		}
+
+		result = s.MustGetRange(ScoreRange{Min: ScoreUnbounded[int](), Max: ScoreUnbounded[int]()})
+		if len(result) != 3 {
+			t.Errorf("Result length for unbounded range expected: %d, got: %d", 3, len(result))
+			return
+		}
+	})
+
+	t.Run("SortedSetCountRange", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		if s.MustCountRange(ScoreRange{Min: ScoreUnbounded[int](), Max: ScoreUnbounded[int]()}) != 0 {
+			t.Errorf("Count of empty sorted set should be zero")
+			return
+		}
+
+		for i := 0; i < 100; i++ {
+			s.Add(strconv.Itoa(i), i/2)
+		}
+
+		if count := s.MustCountRange(halfOpen(10, 20)); count != 20 {
+			t.Errorf("Count of range expected: %d, got: %d", 20, count)
+			return
+		}
+
+		if count := s.MustCountRange(ScoreRange{Min: ScoreExclusive(10), Max: ScoreInclusive(20)}); count != 20 {
+			t.Errorf("Count of range expected: %d, got: %d", 20, count)
+			return
+		}
+
+		if count := s.MustCountRange(ScoreRange{Min: ScoreInclusive(45), Max: ScoreUnbounded[int]()}); count != 10 {
+			t.Errorf("Count of unbounded range expected: %d, got: %d", 10, count)
+			return
+		}
+
+		if count := s.MustCountRange(halfOpen(20, 10)); count != 0 {
+			t.Errorf("Count of inverted range expected: %d, got: %d", 0, count)
+			return
+		}
+	})
+
+	t.Run("SortedSetTieOrdering", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		for _, member := range []string{"d", "b", "e", "a", "c"} {
+			s.Add(member, 5)
+		}
+		s.Add("z", 4)
+		s.Add("y", 6)
+
+		for i := 0; i < 10; i++ {
+			if result := s.MustGet(5); strings.Join(result, ",") != "a,b,c,d,e" {
+				t.Errorf("Members sharing rank are not ordered lexicographically: %v", result)
+				return
+			}
+
+			if result := s.MustGetRange(halfOpen(4, 7)); strings.Join(result, ",") != "z,a,b,c,d,e,y" {
+				t.Errorf("Members of range are not ordered deterministically: %v", result)
+				return
+			}
+
+			if result := s.MustGetRangeReverse(halfOpen(4, 7)); strings.Join(result, ",") != "y,e,d,c,b,a,z" {
+				t.Errorf("Members of reverse range are not ordered deterministically: %v", result)
+				return
+			}
+		}
+	})
+
+	t.Run("SortedSetIndexOf", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		if s.IndexOf("Hello") != -1 {
+			t.Errorf("Returned index for non existant member")
+			return
+		}
+
+		s.Add("World", 5)
+		s.Add("Hello", 5)
+		s.Add("Member1", 1)
+		s.Add("Member2", 9)
+
+		expected := map[string]int{"Member1": 0, "Hello": 1, "World": 2, "Member2": 3}
+		for member, index := range expected {
+			if s.IndexOf(member) != index {
+				t.Errorf("Index of %s expected: %d, got: %d", member, index, s.IndexOf(member))
+				return
+			}
+		}
+
+		s.Remove("Hello")
+		if s.IndexOf("World") != 1 {
+			t.Errorf("Index of World after removal expected: %d, got: %d", 1, s.IndexOf("World"))
+			return
+		}
+	})
+
+	t.Run("SortedSetGetByIndexRange", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		if len(s.GetByIndexRange(0, -1)) != 0 {
+			t.Errorf("Result length should be zero for empty sorted set")
+			return
+		}
+
+		for i := 0; i < 100; i++ {
+			s.Add(strconv.Itoa(i), i/2)
+		}
+
+		result := s.GetByIndexRange(0, -1)
+		if len(result) != 100 {
+			t.Errorf("Result length expected: %d, got: %d", 100, len(result))
+			return
+		}
+
+		for i, member := range result {
+			if s.IndexOf(member) != i {
+				t.Errorf("Index of %s expected: %d, got: %d", member, i, s.IndexOf(member))
+				return
+			}
+		}
+
+		result = s.GetByIndexRange(11, 14)
+		if strings.Join(result, ",") != "11,12,13,14" {
+			t.Errorf("Wrong members returned from index range: %v", result)
+			return
+		}
+
+		result = s.GetByIndexRange(-3, -2)
+		if strings.Join(result, ",") != "97,98" {
+			t.Errorf("Wrong members returned from negative index range: %v", result)
+			return
+		}
+
+		result = s.GetByIndexRange(98, 200)
+		if strings.Join(result, ",") != "98,99" {
+			t.Errorf("Wrong members returned from index range past the end: %v", result)
+			return
+		}
+
+		if len(s.GetByIndexRange(5, 4)) != 0 || len(s.GetByIndexRange(100, 101)) != 0 {
+			t.Errorf("Result length should be zero for empty index range")
+			return
+		}
+	})
+
+	t.Run("SortedSetGetRangeReverse", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		if len(s.MustGetRangeReverse(halfOpen(5, 10))) != 0 {
+			t.Errorf("Result length should be zero for empty sorted set")
+			return
+		}
+
+		for i := 0; i < 10; i++ {
+			s.Add(strconv.Itoa(i), i)
+		}
+
+		result := s.MustGetRangeReverse(halfOpen(3, 7))
+		if strings.Join(result, ",") != "6,5,4,3" {
+			t.Errorf("Wrong members returned from reverse range: %v", result)
+			return
+		}
+
+		result = s.MustGetRangeReverse(halfOpen(8, 100))
+		if strings.Join(result, ",") != "9,8" {
+			t.Errorf("Wrong members returned from reverse range at the end: %v", result)
+			return
+		}
+	})
+
+	t.Run("SortedSetGetByIndexRangeReverse", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		if len(s.GetByIndexRangeReverse(0, -1)) != 0 {
+			t.Errorf("Result length should be zero for empty sorted set")
+			return
+		}
+
+		s.Add("a", 1)
+		s.Add("b", 1)
+		s.Add("c", 2)
+		s.Add("d", 3)
+
+		result := s.GetByIndexRangeReverse(0, -1)
+		if strings.Join(result, ",") != "d,c,b,a" {
+			t.Errorf("Wrong members returned from reverse index range: %v", result)
+			return
+		}
+
+		result = s.GetByIndexRangeReverse(1, 2)
+		if strings.Join(result, ",") != "c,b" {
+			t.Errorf("Wrong members returned from reverse index range: %v", result)
+			return
+		}
+
+		result = s.GetByIndexRangeReverse(-1, 10)
+		if strings.Join(result, ",") != "a" {
+			t.Errorf("Wrong members returned from negative reverse index range: %v", result)
+			return
+		}
+	})
+
+	t.Run("SortedSetStats", func(t *testing.T) {
+		s := SortedSet{}
+		s.Init()
+
+		if s.Len() != 0 || s.Stats() != (Stats{}) {
+			t.Errorf("Stats of empty sorted set should be zero, got: %+v", s.Stats())
+			return
+		}
+
+		for i := 0; i < 100; i++ {
+			s.Add(strconv.Itoa(i), i/2)
+		}
+		s.Add("0", 3)
+		s.IncrementScore("1", 100)
+		s.Remove("99")
+
+		stats := s.Stats()
+		if s.Len() != 99 || stats.Members != 99 || stats.DistinctScores != 50 || stats.Levels < 1 {
+			t.Errorf("Wrong stats of sorted set: %+v", stats)
+			return
+		}
+
+		s.MustPopMin(100)
+		if s.Len() != 0 || s.Stats().DistinctScores != 0 || s.Stats().Levels != 0 {
+			t.Errorf("Stats of emptied sorted set should be zero, got: %+v", s.Stats())
+			return
+		}
 	})
 
 	t.Run("SortedSetExists", func(t *testing.T) {
@@ -202,15 +627,164 @@ func TestSortedSetRead(t *testing.T) {
 		s := SortedSet{}
 		s.Init()
 
-		if s.GetRank("Hello") != -1 {
+		if _, ok := s.GetRank("Hello"); ok {
 			t.Errorf("Returned rank for non existant member")
 			return
 		}
 
 		s.Add("World", 5)
-		if s.GetRank("World") != 5 {
+		if rank, ok := s.GetRank("World"); !ok || rank != 5 {
 			t.Errorf("Returned Wrong rank for member")
 			return
 		}
+
+		s.Add("World", -5)
+		if rank, ok := s.GetRank("World"); !ok || rank != -5 {
+			t.Errorf("Returned Wrong negative rank for member")
+			return
+		}
+	})
+}
+
+func TestGenericSet(t *testing.T) {
+	t.Run("GenericSetFloatScores", func(t *testing.T) {
+		type player struct {
+			Region string
+			ID     int64
+		}
+
+		s := Set[player, float64]{}
+		s.Init()
+
+		s.Add(player{"us", 7}, 1.5)
+		s.Add(player{"eu", 9}, 1.5)
+		s.Add(player{"eu", 3}, 1.5)
+		s.Add(player{"us", 1}, 0.25)
+
+		result := s.MustGetRange(Interval[float64]{Min: ScoreExclusive(0.25), Max: ScoreInclusive(1.5)})
+		if len(result) != 3 || result[0] != (player{"eu", 3}) || result[1] != (player{"eu", 9}) || result[2] != (player{"us", 7}) {
+			t.Errorf("Members sharing rank are not ordered deterministically: %v", result)
+			return
+		}
+
+		if score, err := s.IncrementScore(player{"us", 1}, 2.5); err != nil || score != 2.75 || s.IndexOf(player{"us", 1}) != 3 {
+			t.Errorf("Increment of float rank expected: %v, got: %v", 2.75, score)
+			return
+		}
+
+		page, token, err := s.GetRangePage(allScores[float64](), "", 2)
+		if err != nil || len(page) != 2 || token == "" {
+			t.Errorf("First page returned %v, token: %q, error: %v", page, token, err)
+			return
+		}
+
+		page, token, err = s.GetRangePage(allScores[float64](), token, 2)
+		if err != nil || len(page) != 2 || token != "" || page[0] != (player{"us", 7}) {
+			t.Errorf("Last page returned %v, token: %q, error: %v", page, token, err)
+			return
+		}
+	})
+
+	t.Run("GenericSetIntMembers", func(t *testing.T) {
+		s := Set[int64, uint]{}
+		s.Init()
+
+		for i := int64(0); i < 30; i++ {
+			s.Add(i, uint(i%3))
+		}
+
+		entries, cursor, err := s.Scan("", 100, "1?")
+		if err != nil || cursor != "" || len(entries) != 10 {
+			t.Errorf("Scan of int members returned %v, cursor: %q, error: %v", entries, cursor, err)
+			return
+		}
+
+		if _, ok := s.GetRank(100); ok || s.Exists(100) {
+			t.Errorf("Returned rank for non existant member")
+			return
+		}
+
+		if count := s.MustCountRange(Interval[uint]{Min: ScoreExclusive[uint](0), Max: ScoreUnbounded[uint]()}); count != 20 {
+			t.Errorf("Count of unbounded range expected: %d, got: %d", 20, count)
+			return
+		}
+
+		sameRank := Set[int64, uint]{}
+		for i := int64(0); i < 30; i++ {
+			sameRank.Add(i, 0)
+		}
+
+		if count := sameRank.LexCount(LexInclusive[int64](25), LexUnbounded[int64]()); count != 5 {
+			t.Errorf("Lex count of unbounded range expected: %d, got: %d", 5, count)
+			return
+		}
+
+		popped := s.MustPopMax(2)
+		if len(popped) != 2 || popped[0] != (Element[int64, uint]{Member: 29, Score: 2}) {
+			t.Errorf("Wrong entries popped: %v", popped)
+			return
+		}
+	})
+	t.Run("GenericSetInfiniteAndNaNScores", func(t *testing.T) {
+		s := Set[string, float64]{}
+		s.Init()
+
+		s.Add("a", 0.5)
+		s.Add("b", 2.25)
+		s.Add("top", math.Inf(1))
+
+		if _, err := s.Add("nan", math.NaN()); !errors.Is(err, ErrInvalidScore) || s.Exists("nan") {
+			t.Errorf("NaN rank expected error: %v, got: %v", ErrInvalidScore, err)
+			return
+		}
+
+		if _, err := s.AddWithOptions("nan", math.NaN(), AddOptions{CH: true}); !errors.Is(err, ErrInvalidScore) || s.Exists("nan") {
+			t.Errorf("NaN rank expected error: %v, got: %v", ErrInvalidScore, err)
+			return
+		}
+
+		if score, err := s.IncrementScore("top", math.Inf(-1)); !errors.Is(err, ErrInvalidScore) || !math.IsInf(score, 1) {
+			t.Errorf("Increment resulting in NaN expected error: %v, got: %v", ErrInvalidScore, err)
+			return
+		}
+
+		result := s.MustGetRange(Interval[float64]{Min: ScoreExclusive(0.5), Max: ScoreInclusive(math.Inf(1))})
+		if strings.Join(result, ",") != "b,top" {
+			t.Errorf("Wrong members returned for range ending at +Inf: %v", result)
+			return
+		}
+
+		if count := s.MustCountRange(Interval[float64]{Min: ScoreInclusive(math.Inf(-1)), Max: ScoreExclusive(math.Inf(1))}); count != 2 {
+			t.Errorf("Count of range excluding +Inf expected: %d, got: %d", 2, count)
+			return
+		}
+
+		if _, err := s.GetRange(Interval[float64]{Min: ScoreInclusive(math.NaN()), Max: ScoreUnbounded[float64]()}); !errors.Is(err, ErrInvalidRange) {
+			t.Errorf("Range starting at NaN expected error: %v, got: %v", ErrInvalidRange, err)
+			return
+		}
+
+		if _, err := s.Get(math.NaN()); !errors.Is(err, ErrInvalidScore) {
+			t.Errorf("Get of NaN rank expected error: %v, got: %v", ErrInvalidScore, err)
+			return
+		}
+
+		if popped := s.MustPopMax(1); len(popped) != 1 || popped[0].Member != "top" || !math.IsInf(popped[0].Score, 1) {
+			t.Errorf("Wrong entries popped: %v", popped)
+			return
+		}
+
+		s.Add("bottom", math.Inf(-1))
+		s.Add("below", -0.5)
+		result = s.MustGetRange(Interval[float64]{Min: ScoreInclusive(math.Inf(-1)), Max: ScoreExclusive(0.0)})
+		if strings.Join(result, ",") != "bottom,below" {
+			t.Errorf("Wrong members returned for negative range: %v", result)
+			return
+		}
+
+		if popped := s.MustPopMin(1); len(popped) != 1 || popped[0].Member != "bottom" || !math.IsInf(popped[0].Score, -1) {
+			t.Errorf("Wrong entries popped: %v", popped)
+			return
+		}
 	})
 }